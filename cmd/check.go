@@ -10,6 +10,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/thediveo/enumflag/v2"
+	filehelpers "github.com/turbot/go-kit/files"
 	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
 	"github.com/turbot/steampipe/pkg/cmdconfig"
@@ -80,7 +81,7 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		AddStringSliceFlag(constants.ArgSearchPath, nil, "Set a custom search_path for the steampipe user for a check session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, nil, "Set a prefix to the current search path for a check session (comma-separated)").
 		AddStringFlag(constants.ArgTheme, "dark", "Set the output theme for 'text' output: light, dark or plain").
-		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported formats: csv, html, json, md, nunit3, sps (snapshot), asff").
+		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported formats: csv, html, json, md, nunit3, junit, sarif, sps (snapshot), asff").
 		AddBoolFlag(constants.ArgProgress, true, "Display control execution progress").
 		AddBoolFlag(constants.ArgDryRun, false, "Show which controls will be run without running them").
 		AddStringSliceFlag(constants.ArgTag, nil, "Filter controls based on their tag values ('--tag key=value')").
@@ -90,17 +91,25 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 		// where args passed to StringArrayFlag are not parsed and used raw
 		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable").
 		AddStringFlag(constants.ArgWhere, "", "SQL 'where' clause, or named query, used to filter controls (cannot be used with '--tag')").
+		AddStringSliceFlag(constants.ArgSeverity, nil, fmt.Sprintf("Only run controls with the given severities (comma-separated); one of: %s", strings.Join(constants.ControlSeverities, ", "))).
+		AddStringFlag(constants.ArgFailOnSeverity, "", "Only control alarms with a severity at or above this value affect the exit code").
 		AddIntFlag(constants.ArgDatabaseQueryTimeout, constants.DatabaseDefaultCheckQueryTimeout, "The query timeout").
 		AddIntFlag(constants.ArgMaxParallel, constants.DefaultMaxConnections, "The maximum number of concurrent database connections to open").
+		AddIntFlag(constants.ArgControlTimeout, 0, "The timeout for each control execution attempt, in seconds (0 for no timeout)").
+		AddIntFlag(constants.ArgControlRetries, 0, "The number of times to retry a control which errors").
+		AddIntFlag(constants.ArgControlRetryBackoff, constants.DefaultControlRetryBackoffSecs, "The delay before retrying a control which errors, in seconds - doubled for each subsequent retry").
 		AddBoolFlag(constants.ArgModInstall, true, "Specify whether to install mod dependencies before running the check").
 		AddBoolFlag(constants.ArgInput, true, "Enable interactive prompts").
 		AddBoolFlag(constants.ArgSnapshot, false, "Create snapshot in Turbot Pipes with the default (workspace) visibility").
 		AddBoolFlag(constants.ArgShare, false, "Create snapshot in Turbot Pipes with 'anyone_with_link' visibility").
 		AddStringArrayFlag(constants.ArgSnapshotTag, nil, "Specify tags to set on the snapshot").
 		AddStringFlag(constants.ArgSnapshotLocation, "", "The location to write snapshots - either a local file path or a Turbot Pipes workspace").
-		AddStringFlag(constants.ArgSnapshotTitle, "", "The title to give a snapshot")
+		AddStringFlag(constants.ArgSnapshotTitle, "", "The title to give a snapshot").
+		AddStringFlag(constants.ArgBaseline, "", "A previous JSON export of check results - only alarms and errors not present in the baseline affect the exit code").
+		AddBoolFlag(constants.ArgUpdateBaseline, false, "Write the results of this run to the '--baseline' file")
 
 	cmd.AddCommand(getListSubCmd(listSubCmdOptions{parentCmd: cmd}))
+	cmd.AddCommand(checkDiffCmd())
 	return cmd
 }
 
@@ -108,6 +117,8 @@ You may specify one or more benchmarks or controls to run (separated by a space)
 // exitCode=1 no runtime errors, 1 or more control alarms, no control errors
 // exitCode=2 no runtime errors, 1 or more control errors
 // exitCode=3+ runtime errors
+// if a baseline is provided, only control alarms and errors which are not in the baseline are considered
+// if '--fail-on-severity' is set, only control alarms at or above that severity are considered
 
 func runCheckCmd(cmd *cobra.Command, args []string) {
 	utils.LogTime("runCheckCmd start")
@@ -164,6 +175,10 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 	// pull out useful properties
 	totalAlarms, totalErrors := 0, 0
 
+	// load the baseline (if specified)
+	baseline, err := loadBaseline()
+	error_helpers.FailOnError(err)
+
 	// get the execution trees
 	// depending on the set of arguments and the export targets, we may get more than one
 	// example :
@@ -174,15 +189,24 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 
 	// execute controls synchronously (execute returns the number of alarms and errors)
 	for _, namedTree := range trees {
-		err = executeTree(ctx, namedTree.tree, initData)
+		err = executeTree(ctx, namedTree.tree, initData, baseline)
 		if err != nil {
 			error_helpers.ShowError(ctx, err)
 			continue
 		}
 
 		// append the total number of alarms and errors for multiple runs
-		totalAlarms += namedTree.tree.Root.Summary.Status.Alarm
-		totalErrors += namedTree.tree.Root.Summary.Status.Error
+		alarms, errors := namedTree.tree.Root.Summary.Status.Alarm, namedTree.tree.Root.Summary.Status.Error
+		// if there is a baseline, only count the alarms and errors which are not in the baseline
+		if baselineSummary := namedTree.tree.Baseline; baselineSummary != nil {
+			alarms, errors = baselineSummary.NewAlarms, baselineSummary.NewErrors
+		}
+		// if a severity threshold is set, only count the alarms at or above the threshold
+		if failOnSeverity := viper.GetString(constants.ArgFailOnSeverity); failOnSeverity != "" {
+			alarms = namedTree.tree.AlarmCountAtSeverity(failOnSeverity)
+		}
+		totalAlarms += alarms
+		totalErrors += errors
 
 		err = publishSnapshot(ctx, namedTree.tree, viper.GetBool(constants.ArgShare), viper.GetBool(constants.ArgSnapshot))
 		if err != nil {
@@ -191,6 +215,7 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		}
 
 		printTiming(namedTree.tree)
+		printBaselineSummary(namedTree.tree)
 
 		err = exportExecutionTree(ctx, namedTree, initData, viper.GetStringSlice(constants.ArgExport))
 		if err != nil {
@@ -199,10 +224,47 @@ func runCheckCmd(cmd *cobra.Command, args []string) {
 		}
 	}
 
+	if viper.GetBool(constants.ArgUpdateBaseline) && !error_helpers.IsContextCanceled(ctx) {
+		err = updateBaseline(ctx, trees)
+		if err != nil {
+			error_helpers.ShowError(ctx, err)
+		}
+	}
+
 	// set the defined exit code after successful execution
 	exitCode = getExitCode(totalAlarms, totalErrors)
 }
 
+// loadBaseline loads the baseline file specified by the '--baseline' arg
+// if '--update-baseline' is set, the file does not need to exist
+func loadBaseline() (*controlexecute.Baseline, error) {
+	baselinePath := viper.GetString(constants.ArgBaseline)
+	if baselinePath == "" {
+		return nil, nil
+	}
+	if viper.GetBool(constants.ArgUpdateBaseline) && !filehelpers.FileExists(baselinePath) {
+		return nil, nil
+	}
+	return controlexecute.LoadBaseline(baselinePath)
+}
+
+// updateBaseline writes the results of all execution trees to the baseline file
+func updateBaseline(ctx context.Context, trees []*namedExecutionTree) error {
+	statushooks.Show(ctx)
+	defer statushooks.Done(ctx)
+	statushooks.SetStatus(ctx, "Updating baseline")
+
+	executionTrees := make([]*controlexecute.ExecutionTree, len(trees))
+	for i, namedTree := range trees {
+		executionTrees[i] = namedTree.tree
+	}
+	baselinePath := viper.GetString(constants.ArgBaseline)
+	if err := controldisplay.ExportBaseline(ctx, baselinePath, executionTrees...); err != nil {
+		return sperr.WrapWithMessage(err, "failed to update baseline")
+	}
+	return nil
+}
+
 // exportExecutionTree relies on the fact that the given tree is already executed
 func exportExecutionTree(ctx context.Context, namedTree *namedExecutionTree, initData *control.InitData, exportArgs []string) error {
 	statushooks.Show(ctx)
@@ -228,7 +290,8 @@ func exportExecutionTree(ctx context.Context, namedTree *namedExecutionTree, ini
 }
 
 // executeTree executes and displays the (table) results of an execution
-func executeTree(ctx context.Context, tree *controlexecute.ExecutionTree, initData *control.InitData) error {
+// if a baseline is provided, the results are compared with the baseline before they are displayed
+func executeTree(ctx context.Context, tree *controlexecute.ExecutionTree, initData *control.InitData, baseline *controlexecute.Baseline) error {
 	// create a context with check status hooks
 	checkCtx := createCheckContext(ctx)
 	err := tree.Execute(checkCtx)
@@ -236,6 +299,10 @@ func executeTree(ctx context.Context, tree *controlexecute.ExecutionTree, initDa
 		return err
 	}
 
+	if baseline != nil {
+		baseline.Apply(tree)
+	}
+
 	err = displayControlResults(checkCtx, tree, initData.OutputFormatter)
 	if err != nil {
 		return err
@@ -352,6 +419,32 @@ func validateCheckArgs(ctx context.Context, cmd *cobra.Command, args []string) b
 		return false
 	}
 
+	// '--update-baseline' requires a '--baseline' file
+	if viper.GetBool(constants.ArgUpdateBaseline) && viper.GetString(constants.ArgBaseline) == "" {
+		error_helpers.ShowError(ctx, fmt.Errorf("'--%s' requires '--%s' to be set", constants.ArgUpdateBaseline, constants.ArgBaseline))
+		return false
+	}
+
+	// control timeout and retry values must not be negative
+	for _, arg := range []string{constants.ArgControlTimeout, constants.ArgControlRetries, constants.ArgControlRetryBackoff} {
+		if viper.GetInt(arg) < 0 {
+			error_helpers.ShowError(ctx, fmt.Errorf("'--%s' must not be negative", arg))
+			return false
+		}
+	}
+
+	// '--severity' and '--fail-on-severity' values must be valid severities
+	for _, severity := range viper.GetStringSlice(constants.ArgSeverity) {
+		if !controlexecute.IsValidSeverity(severity) {
+			error_helpers.ShowError(ctx, fmt.Errorf("invalid '--%s' value '%s'; must be one of: %s", constants.ArgSeverity, severity, strings.Join(constants.ControlSeverities, ", ")))
+			return false
+		}
+	}
+	if failOnSeverity := viper.GetString(constants.ArgFailOnSeverity); failOnSeverity != "" && !controlexecute.IsValidSeverity(failOnSeverity) {
+		error_helpers.ShowError(ctx, fmt.Errorf("invalid '--%s' value '%s'; must be one of: %s", constants.ArgFailOnSeverity, failOnSeverity, strings.Join(constants.ControlSeverities, ", ")))
+		return false
+	}
+
 	// if both '--where' and '--tag' have been used, then it's an error
 	if viper.IsSet(constants.ArgWhere) && viper.IsSet(constants.ArgTag) {
 		error_helpers.ShowError(ctx, fmt.Errorf("only 1 of '--%s' and '--%s' may be set", constants.ArgWhere, constants.ArgTag))
@@ -388,6 +481,24 @@ func printTiming(tree *controlexecute.ExecutionTree) {
 	display.ShowWrappedTable(headers, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
 }
 
+func printBaselineSummary(tree *controlexecute.ExecutionTree) {
+	summary := tree.Baseline
+	if summary == nil || !shouldPrintBaselineSummary() {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("Baseline: %d new, %d unchanged, %d resolved\n", summary.New, summary.Unchanged, len(summary.Resolved))
+	for _, row := range summary.Resolved {
+		fmt.Printf("  resolved: %s %s\n", row.ControlId, row.Resource)
+	}
+}
+
+func shouldPrintBaselineSummary() bool {
+	outputFormat := viper.GetString(constants.ArgOutput)
+	return !viper.GetBool(constants.ArgDryRun) &&
+		(outputFormat == constants.OutputFormatText || outputFormat == constants.OutputFormatBrief)
+}
+
 func shouldPrintTiming() bool {
 	outputFormat := viper.GetString(constants.ArgOutput)
 	timingMode := viper.GetString(constants.ArgTiming)
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/thediveo/enumflag/v2"
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controldiff"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+)
+
+// variable used to assign the output mode flag
+var checkDiffOutputMode = constants.CheckDiffOutputModeTable
+
+func checkDiffCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "diff [flags] <before> <after>",
+		TraverseChildren: true,
+		Args:             cobra.ExactArgs(2),
+		Run:              runCheckDiffCmd,
+		Short:            "Compare the results of two check runs",
+		Long: `Compare the results of two check runs.
+
+Each argument must be either a check JSON export (--export=json) or a snapshot (--export=sps).
+Reports the controls whose status counts have changed, and the resources whose status has changed.`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddBoolFlag(constants.ArgHelp, false, "Help for check diff", cmdconfig.FlagOptions.WithShortHand("h")).
+		AddVarFlag(enumflag.New(&checkDiffOutputMode, constants.ArgOutput, constants.CheckDiffOutputModeIds, enumflag.EnumCaseInsensitive),
+			constants.ArgOutput,
+			fmt.Sprintf("Output format; one of: %s", strings.Join(constants.FlagValues(constants.CheckDiffOutputModeIds), ", ")))
+
+	return cmd
+}
+
+func runCheckDiffCmd(cmd *cobra.Command, args []string) {
+	before, err := controldiff.LoadCheckResults(args[0])
+	error_helpers.FailOnError(err)
+	after, err := controldiff.LoadCheckResults(args[1])
+	error_helpers.FailOnError(err)
+
+	diff := controldiff.Diff(before, after)
+
+	switch viper.GetString(constants.ArgOutput) {
+	case constants.OutputFormatJSON:
+		res, err := diff.Json()
+		error_helpers.FailOnError(err)
+		fmt.Println(res)
+	case constants.OutputFormatMD:
+		fmt.Print(diff.Markdown())
+	default:
+		showCheckDiffTables(diff)
+	}
+}
+
+func showCheckDiffTables(diff *controldiff.CheckDiff) {
+	tableOptions := &display.ShowWrappedTableOptions{AutoMerge: false, Truncate: true}
+
+	fmt.Println("Controls:")
+	if len(diff.Controls) == 0 {
+		fmt.Println("No controls changed.")
+	} else {
+		headers, rows := diff.ControlTable()
+		display.ShowWrappedTable(headers, rows, tableOptions)
+	}
+	fmt.Println()
+
+	fmt.Println("Resources:")
+	if len(diff.Transitions) == 0 {
+		fmt.Println("No resource status changes.")
+	} else {
+		headers, rows := diff.TransitionTable()
+		display.ShowWrappedTable(headers, rows, tableOptions)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
 	"strings"
 
@@ -404,14 +405,18 @@ func onServerStarted(ctx context.Context, serverPort dashboardserver.ListenPort,
 }
 
 func buildDashboardURL(serverPort dashboardserver.ListenPort, w *workspace.Workspace) string {
-	url := fmt.Sprintf("http://localhost:%d", serverPort)
+	dashboardURL := fmt.Sprintf("%s://localhost:%d", dashboardserver.URLScheme(), serverPort)
 	if len(w.SourceSnapshots) == 1 {
 		for snapshotName := range w.GetResourceMaps().Snapshots {
-			url += fmt.Sprintf("/%s", snapshotName)
+			dashboardURL += fmt.Sprintf("/%s", snapshotName)
 			break
 		}
 	}
-	return url
+	// if token auth is enabled, pass the token so the browser is authenticated
+	if token := viper.GetString(constants.ArgDashboardAuthToken); token != "" {
+		dashboardURL += fmt.Sprintf("?%s=%s", dashboardserver.AuthTokenQueryParam, url.QueryEscape(token))
+	}
+	return dashboardURL
 }
 
 // is this dashboard server running as a service?
@@ -442,6 +447,7 @@ func saveDashboardState(serverPort dashboardserver.ListenPort, serverListen dash
 		Port:       int(serverPort),
 		ListenType: string(serverListen),
 		Listen:     constants.DashboardListenAddresses,
+		TLS:        dashboardserver.TLSEnabled(),
 	}
 
 	if serverListen == dashboardserver.ListenTypeNetwork {
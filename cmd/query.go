@@ -23,9 +23,12 @@ import (
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
 	"github.com/turbot/steampipe/pkg/display"
 	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/export"
 	"github.com/turbot/steampipe/pkg/query"
 	"github.com/turbot/steampipe/pkg/query/queryexecute"
+	"github.com/turbot/steampipe/pkg/query/queryparams"
 	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/query/querywatch"
 	"github.com/turbot/steampipe/pkg/statushooks"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/utils"
@@ -38,6 +41,12 @@ var queryTimingMode = constants.QueryTimingModeOff
 // variable used to assign the output mode flag
 var queryOutputMode = constants.QueryOutputModeTable
 
+// variable used to assign the explain mode flag
+var queryExplainMode = constants.QueryExplainModeOff
+
+// variable used to assign the on error mode flag
+var queryOnErrorMode = constants.QueryOnErrorModeContinue
+
 func queryCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:              "query",
@@ -92,6 +101,10 @@ Examples:
 			constants.ArgTiming,
 			fmt.Sprintf("Display query timing; one of: %s", strings.Join(constants.FlagValues(constants.QueryTimingModeIds), ", ")),
 			cmdconfig.FlagOptions.NoOptDefVal(constants.ArgOn)).
+		AddVarFlag(enumflag.New(&queryExplainMode, constants.ArgExplain, constants.QueryExplainModeIds, enumflag.EnumCaseInsensitive),
+			constants.ArgExplain,
+			fmt.Sprintf("Show the query plan instead of the query results; one of: %s ('analyze' executes the query and shows the plugin scans)", strings.Join(constants.FlagValues(constants.QueryExplainModeIds), ", ")),
+			cmdconfig.FlagOptions.NoOptDefVal(constants.ArgOn)).
 		AddBoolFlag(constants.ArgWatch, true, "Watch SQL files in the current workspace (works only in interactive mode)").
 		AddStringSliceFlag(constants.ArgSearchPath, nil, "Set a custom search_path for the steampipe user for a query session (comma-separated)").
 		AddStringSliceFlag(constants.ArgSearchPathPrefix, nil, "Set a prefix to the current search path for a query session (comma-separated)").
@@ -100,17 +113,25 @@ Examples:
 		// Cobra will interpret values passed to a StringSliceFlag as CSV,
 		// where args passed to StringArrayFlag are not parsed and used raw
 		AddStringArrayFlag(constants.ArgVariable, nil, "Specify the value of a variable").
+		// NOTE: use StringArrayFlag for ArgParam, as param values may contain commas
+		AddStringArrayFlag(constants.ArgParam, nil, "Specify the value of a query param, referenced in queries as :name (e.g. --param region=us-east-1)").
+		AddStringFlag(constants.ArgWatchInterval, "", "Re-run the query at this interval (e.g. 30s), redrawing the result and highlighting changed rows").
+		AddBoolFlag(constants.ArgSingleTransaction, false, "Run all the queries and statements in a single transaction, which is rolled back if any of them fail").
+		AddVarFlag(enumflag.New(&queryOnErrorMode, constants.ArgOnError, constants.QueryOnErrorModeIds, enumflag.EnumCaseInsensitive),
+			constants.ArgOnError,
+			fmt.Sprintf("Whether to continue running the remaining queries and statements after one fails; one of: %s", strings.Join(constants.FlagValues(constants.QueryOnErrorModeIds), ", "))).
 		AddBoolFlag(constants.ArgInput, true, "Enable interactive prompts").
 		AddBoolFlag(constants.ArgSnapshot, false, "Create snapshot in Turbot Pipes with the default (workspace) visibility").
 		AddBoolFlag(constants.ArgShare, false, "Create snapshot in Turbot Pipes with 'anyone_with_link' visibility").
 		AddStringArrayFlag(constants.ArgSnapshotTag, nil, "Specify tags to set on the snapshot").
 		AddStringFlag(constants.ArgSnapshotTitle, "", "The title to give a snapshot").
 		AddIntFlag(constants.ArgDatabaseQueryTimeout, 0, "The query timeout").
-		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported format: sps (snapshot)").
+		AddStringSliceFlag(constants.ArgExport, nil, "Export output to file, supported formats: csv, json, md, html, jsonl, yaml, parquet, sps (snapshot)").
 		AddStringFlag(constants.ArgSnapshotLocation, "", "The location to write snapshots - either a local file path or a Turbot Pipes workspace").
 		AddBoolFlag(constants.ArgProgress, true, "Display snapshot upload status")
 
 	cmd.AddCommand(getListSubCmd(listSubCmdOptions{parentCmd: cmd}))
+	cmd.AddCommand(queryHistorySubCmd())
 
 	return cmd
 }
@@ -196,6 +217,50 @@ func validateQueryArgs(ctx context.Context, args []string) error {
 		exitCode = constants.ExitCodeInsufficientOrWrongInputs
 		return sperr.New("cannot export query results in interactive mode")
 	}
+	if viper.GetString(constants.ArgExplain) != constants.ArgOff {
+		if interactiveMode {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("cannot use --explain in interactive mode - use the %s metaquery instead", constants.CmdExplain)
+		}
+		if snapshotRequired() || len(viper.GetStringSlice(constants.ArgExport)) > 0 {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("--explain cannot be used with snapshots or exports")
+		}
+	}
+	if params := viper.GetStringSlice(constants.ArgParam); len(params) > 0 {
+		if _, err := queryparams.ParseArgs(params); err != nil {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return err
+		}
+		if snapshotRequired() {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("--param cannot be used with snapshots")
+		}
+	}
+	if viper.IsSet(constants.ArgWatchInterval) {
+		if _, err := querywatch.ParseInterval(viper.GetString(constants.ArgWatchInterval)); err != nil {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return err
+		}
+		if interactiveMode {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("cannot use --%s in interactive mode - use the %s metaquery instead", constants.ArgWatchInterval, constants.CmdWatch)
+		}
+		if snapshotRequired() || len(viper.GetStringSlice(constants.ArgExport)) > 0 || viper.GetString(constants.ArgExplain) != constants.ArgOff {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("--%s cannot be used with snapshots, exports or --explain", constants.ArgWatchInterval)
+		}
+	}
+	if viper.GetBool(constants.ArgSingleTransaction) {
+		if interactiveMode {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("cannot use --%s in interactive mode", constants.ArgSingleTransaction)
+		}
+		if snapshotRequired() || viper.GetString(constants.ArgExplain) != constants.ArgOff || viper.IsSet(constants.ArgWatchInterval) {
+			exitCode = constants.ExitCodeInsufficientOrWrongInputs
+			return sperr.New("--%s cannot be used with snapshots, --explain or --%s", constants.ArgSingleTransaction, constants.ArgWatchInterval)
+		}
+	}
 	// if share or snapshot args are set, there must be a query specified
 	err := cmdconfig.ValidateSnapshotArgs(ctx)
 	if err != nil {
@@ -203,7 +268,7 @@ func validateQueryArgs(ctx context.Context, args []string) error {
 		return err
 	}
 
-	validOutputFormats := []string{constants.OutputFormatLine, constants.OutputFormatCSV, constants.OutputFormatTable, constants.OutputFormatJSON, constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort, constants.OutputFormatNone}
+	validOutputFormats := []string{constants.OutputFormatLine, constants.OutputFormatCSV, constants.OutputFormatTable, constants.OutputFormatJSON, constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort, constants.OutputFormatParquet, constants.OutputFormatArrow, constants.OutputFormatMD, constants.OutputFormatHTML, constants.OutputFormatJSONL, constants.OutputFormatYAML, constants.OutputFormatNone}
 	output := viper.GetString(constants.ArgOutput)
 	if !helpers.StringSliceContains(validOutputFormats, output) {
 		exitCode = constants.ExitCodeInsufficientOrWrongInputs
@@ -279,8 +344,7 @@ func executeSnapshotQuery(initData *query.InitData, ctx context.Context) int {
 		}
 
 		// export the result if necessary
-		exportArgs := viper.GetStringSlice(constants.ArgExport)
-		exportMsg, err := initData.ExportManager.DoExport(ctx, snap.FileNameRoot, snap, exportArgs)
+		exportMsg, err := exportSnapshotQuery(ctx, initData, snap, viper.GetStringSlice(constants.ArgExport))
 		if err != nil {
 			exitCode = constants.ExitCodeSnapshotCreationFailed
 			error_helpers.FailOnErrorWithMessage(err, "failed to export snapshot")
@@ -295,6 +359,29 @@ func executeSnapshotQuery(initData *query.InitData, ctx context.Context) int {
 	return 0
 }
 
+// exportSnapshotQuery exports the snapshot to any snapshot export targets,
+// and the query result of the snapshot to any other export targets
+func exportSnapshotQuery(ctx context.Context, initData *query.InitData, snap *dashboardtypes.SteampipeSnapshot, exportArgs []string) ([]string, error) {
+	var exportMsg []string
+	for _, exportArg := range exportArgs {
+		var source export.ExportSourceData = snap
+		if !isSnapshotExport(exportArg) {
+			// each export reads the result rows, so create a new result for each
+			result, err := snapshotToQueryResult(snap)
+			if err != nil {
+				return nil, err
+			}
+			source = result
+		}
+		msg, err := initData.ExportManager.DoExport(ctx, snap.FileNameRoot, source, []string{exportArg})
+		if err != nil {
+			return nil, err
+		}
+		exportMsg = append(exportMsg, msg...)
+	}
+	return exportMsg, nil
+}
+
 func snapshotToQueryResult(snap *dashboardtypes.SteampipeSnapshot) (*queryresult.Result, error) {
 	// the table of a snapshot query has a fixed name
 	tablePanel, ok := snap.Panels[modconfig.SnapshotQueryTableName]
@@ -357,18 +444,24 @@ func ensureSnapshotQueryResource(name string, resolvedQuery *modconfig.ResolvedQ
 	return q, false
 }
 
+var snapshotFormatNames = []string{constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort}
+
+// isSnapshotExport returns whether the export arg is a snapshot format or file
+func isSnapshotExport(exportArg string) bool {
+	return helpers.StringSliceContains(snapshotFormatNames, exportArg) || path.Ext(exportArg) == constants.SnapshotExtension
+}
+
 func snapshotRequired() bool {
-	SnapshotFormatNames := []string{constants.OutputFormatSnapshot, constants.OutputFormatSnapshotShort}
 	// if a snapshot exporter is specified return true
 	for _, e := range viper.GetStringSlice(constants.ArgExport) {
-		if helpers.StringSliceContains(SnapshotFormatNames, e) || path.Ext(e) == constants.SnapshotExtension {
+		if isSnapshotExport(e) {
 			return true
 		}
 	}
 	// if share/snapshot args are set or output is snapshot, return true
 	return viper.IsSet(constants.ArgShare) ||
 		viper.IsSet(constants.ArgSnapshot) ||
-		helpers.StringSliceContains(SnapshotFormatNames, viper.GetString(constants.ArgOutput))
+		helpers.StringSliceContains(snapshotFormatNames, viper.GetString(constants.ArgOutput))
 
 }
 
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+)
+
+func queryHistorySubCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:              "history [search]",
+		TraverseChildren: true,
+		Args:             cobra.ArbitraryArgs,
+		Run:              runQueryHistorySubCmd,
+		Short:            "List, search and re-run queries from the interactive query history",
+		Long: `List, search and re-run queries from the interactive query history.
+
+Query history is stored separately for each workspace. If a search string is
+passed, only queries containing the search string are listed.
+
+Examples:
+
+  # List the query history for the current workspace
+  steampipe query history
+
+  # List the 10 most recent queries which reference the aws_s3_bucket table
+  steampipe query history aws_s3_bucket --limit 10
+
+  # Re-run the query with id 42, displaying the results as JSON
+  # (query flags must be passed before the history subcommand)
+  steampipe query --output json history --run 42`,
+	}
+
+	cmdconfig.
+		OnCmd(cmd).
+		AddModLocationFlag().
+		AddIntFlag(constants.ArgHistoryLimit, 0, "Only list the most recent n matching queries").
+		AddIntFlag(constants.ArgHistoryRun, 0, "Re-run the query with the given id")
+
+	return cmd
+}
+
+func runQueryHistorySubCmd(cmd *cobra.Command, args []string) {
+	history, err := queryhistory.New(viper.GetString(constants.ArgModLocation))
+	error_helpers.FailOnErrorWithMessage(err, "failed to load query history")
+
+	if id := viper.GetInt(constants.ArgHistoryRun); id != 0 {
+		entry, err := history.GetEntry(id)
+		error_helpers.FailOnError(err)
+
+		// bind the flags of the parent query command, so the query is executed using them
+		cmd.Parent().Flags().VisitAll(func(f *pflag.Flag) {
+			if cmd.Flags().Lookup(f.Name) == nil {
+				//nolint:golint,errcheck // flag is not nil
+				viper.BindPFlag(f.Name, f)
+			}
+		})
+		runQueryCmd(cmd, []string{entry.Query})
+		return
+	}
+
+	search := strings.Join(args, " ")
+	entries := history.Search(search)
+	if limit := viper.GetInt(constants.ArgHistoryLimit); limit > 0 && len(entries) > limit {
+		entries = entries[len(entries)-limit:]
+	}
+	if len(entries) == 0 {
+		if search == "" {
+			fmt.Println("No query history.")
+		} else {
+			fmt.Printf("No queries matching '%s' found in the history.\n", search)
+		}
+		return
+	}
+
+	headers, rows := queryhistory.TableRows(entries)
+	display.ShowWrappedTable(headers, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
+}
@@ -742,7 +742,11 @@ Database:
 	dashboardMsg := ""
 
 	if dashboardState != nil {
-		browserUrl := fmt.Sprintf("http://%s:%d/", dashboardState.Listen[0], dashboardState.Port)
+		scheme := "http"
+		if dashboardState.TLS {
+			scheme = "https"
+		}
+		browserUrl := fmt.Sprintf("%s://%s:%d/", scheme, dashboardState.Listen[0], dashboardState.Port)
 		dashboardMsg = fmt.Sprintf(`
 Dashboard:
 
@@ -11,6 +11,7 @@ import (
 	steampipecloud "github.com/turbot/steampipe-cloud-sdk-go"
 	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
 	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardsnapshot"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
 	"github.com/turbot/steampipe/pkg/export"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
@@ -51,6 +52,15 @@ func exportSnapshot(snapshot *dashboardtypes.SteampipeSnapshot) (string, error)
 	if err != nil {
 		return "", sperr.Wrap(err)
 	}
+
+	// the snapshot location is a snapshot library - record the title and tags, so the snapshot can be searched for
+	tags := make(map[string]string)
+	for k, v := range getTags() {
+		tags[k] = fmt.Sprintf("%v", v)
+	}
+	if err := dashboardsnapshot.NewLibrary(dirName).Add(fileName, resolveSnapshotTitle(snapshot), tags); err != nil {
+		log.Printf("[WARN] failed to add snapshot %s to the snapshot library: %s", filePath, err.Error())
+	}
 	return filePath, nil
 }
 
@@ -103,8 +103,9 @@ func setBaseDefaults() error {
 		constants.ArgPipesInstallDir: pipesInstallDir,
 
 		// workspace profile
-		constants.ArgAutoComplete:  true,
-		constants.ArgIntrospection: constants.IntrospectionNone,
+		constants.ArgAutoComplete:           true,
+		constants.ConfigKeyAutoCompleteMode: constants.ArgOn,
+		constants.ArgIntrospection:          constants.IntrospectionNone,
 
 		// from global database options
 		constants.ArgDatabasePort:         constants.DatabaseDefaultPort,
@@ -171,6 +172,8 @@ func setDefaultsFromEnv() {
 		constants.EnvDatabaseStartTimeout:  {[]string{constants.ArgDatabaseStartTimeout}, Int},
 		constants.EnvDatabaseSSLPassword:   {[]string{constants.ArgDatabaseSSLPassword}, String},
 		constants.EnvDashboardStartTimeout: {[]string{constants.ArgDashboardStartTimeout}, Int},
+		constants.EnvDashboardAuthToken:    {[]string{constants.ArgDashboardAuthToken}, String},
+		constants.EnvDashboardAuthPassword: {[]string{constants.ArgDashboardAuthPassword}, String},
 		constants.EnvCacheTTL:              {[]string{constants.ArgCacheTtl}, Int},
 		constants.EnvCacheMaxTTL:           {[]string{constants.ArgCacheMaxTtl}, Int},
 		constants.EnvMemoryMaxMb:           {[]string{constants.ArgMemoryMaxMb}, Int},
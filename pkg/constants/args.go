@@ -11,6 +11,8 @@ const (
 	ArgOff                     = "off"
 	ArgVerbose                 = "verbose"
 	ArgClear                   = "clear"
+	ArgBasic                   = "basic"
+	ArgFull                    = "full"
 	ArgDatabaseListenAddresses = "database-listen"
 	ArgDatabasePort            = "database-port"
 	ArgDatabaseQueryTimeout    = "query-timeout"
@@ -71,6 +73,38 @@ const (
 	ArgDatabaseSSLPassword     = "database-ssl-password"
 	ArgMemoryMaxMb             = "memory-max-mb"
 	ArgMemoryMaxMbPlugin       = "memory-max-mb-plugin"
+	ArgBaseline                = "baseline"
+	ArgUpdateBaseline          = "update-baseline"
+	ArgSeverity                = "severity"
+	ArgFailOnSeverity          = "fail-on-severity"
+	ArgControlTimeout          = "control-timeout"
+	ArgControlRetries          = "control-retries"
+	ArgControlRetryBackoff     = "control-retry-backoff"
+	ArgHistoryLimit            = "limit"
+	ArgHistoryRun              = "run"
+	ArgExplain                 = "explain"
+	ArgAnalyze                 = "analyze"
+	ArgParam                   = "param"
+	ArgWatchInterval           = "watch-interval"
+	ArgSingleTransaction       = "single-transaction"
+	ArgOnError                 = "on-error"
+	ArgStop                    = "stop"
+	ArgContinue                = "continue"
+
+	// dashboard server authentication
+	ArgDashboardAuthToken          = "dashboard-auth-token"
+	ArgDashboardAuthUsername       = "dashboard-auth-username"
+	ArgDashboardAuthPassword       = "dashboard-auth-password"
+	ArgDashboardAuthProxyHeader    = "dashboard-auth-proxy-header"
+	ArgDashboardAuthTrustedProxies = "dashboard-auth-trusted-proxies"
+
+	// dashboard server tls
+	ArgDashboardTLS     = "dashboard-tls"
+	ArgDashboardTLSCert = "dashboard-tls-cert"
+	ArgDashboardTLSKey  = "dashboard-tls-key"
+
+	// dashboard server rest api
+	ArgDashboardEnableQueryAPI = "dashboard-enable-query-api"
 )
 
 // metaquery mode arguments
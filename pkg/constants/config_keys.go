@@ -10,4 +10,7 @@ const (
 	ConfigKeyServerSearchPath            = "server-search-path"
 	ConfigKeyServerSearchPathPrefix      = "server-search-path-prefix"
 	ConfigKeyBypassHomeDirModfileWarning = "bypass-home-dir-modfile-warning"
+	ConfigKeyOutputFile                  = "output-file"
+	ConfigKeyOnceOutputFile              = "once-output-file"
+	ConfigKeyAutoCompleteMode            = "autocomplete-mode"
 )
@@ -6,4 +6,6 @@ const (
 	// MaxControlRunAttempts determines how many time should a cotnrol run should be retried
 	// in the case of a GRPC connectivity error
 	MaxControlRunAttempts = 2
+	// DefaultControlRetryBackoffSecs is the default number of seconds to wait before retrying a control which errored
+	DefaultControlRetryBackoffSecs = 5
 )
@@ -0,0 +1,18 @@
+package constants
+
+const (
+	ControlSeverityNone     = "none"
+	ControlSeverityLow      = "low"
+	ControlSeverityMedium   = "medium"
+	ControlSeverityHigh     = "high"
+	ControlSeverityCritical = "critical"
+)
+
+// ControlSeverities is the list of valid control severities, ordered from lowest to highest
+var ControlSeverities = []string{
+	ControlSeverityNone,
+	ControlSeverityLow,
+	ControlSeverityMedium,
+	ControlSeverityHigh,
+	ControlSeverityCritical,
+}
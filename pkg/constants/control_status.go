@@ -6,4 +6,6 @@ const (
 	ControlSkip  = "skip"
 	ControlInfo  = "info"
 	ControlError = "error"
+	// ControlSuppressed is not returned by control queries - it is set for rows which match an exemption
+	ControlSuppressed = "suppressed"
 )
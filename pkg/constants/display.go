@@ -11,4 +11,9 @@ const (
 
 	// NullString is the string which is displayed for null column values
 	NullString = "<null>"
+
+	// ResultCacheSize is the number of query results kept in memory by the interactive client, to be re-displayed
+	ResultCacheSize = 10
+	// MaxCachedResultRows is the maximum number of rows of a result which is kept in memory - larger results are not cached
+	MaxCachedResultRows = 10000
 )
@@ -11,6 +11,8 @@ const (
 	EnvDatabaseStartTimeout  = "STEAMPIPE_DATABASE_START_TIMEOUT"
 	EnvDatabaseSSLPassword   = "STEAMPIPE_DATABASE_SSL_PASSWORD"
 	EnvDashboardStartTimeout = "STEAMPIPE_DASHBOARD_START_TIMEOUT"
+	EnvDashboardAuthToken    = "STEAMPIPE_DASHBOARD_AUTH_TOKEN"
+	EnvDashboardAuthPassword = "STEAMPIPE_DASHBOARD_AUTH_PASSWORD"
 
 	EnvSnapshotLocation  = "STEAMPIPE_SNAPSHOT_LOCATION"
 	EnvWorkspaceDatabase = "STEAMPIPE_WORKSPACE_DATABASE"
@@ -13,6 +13,8 @@ const (
 	JsonExtension        = ".json"
 	TextExtension        = ".txt"
 	SnapshotExtension    = ".sps"
+	SarifExtension       = ".sarif"
+	JUnitExtension       = ".junit.xml"
 	TokenExtension       = ".tptt"
 	LegacyTokenExtension = ".sptt"
 )
@@ -14,6 +14,12 @@ const (
 	QueryOutputModeSnapshot
 	QueryOutputModeSnapshotShort
 	QueryOutputModeTable
+	QueryOutputModeParquet
+	QueryOutputModeArrow
+	QueryOutputModeMd
+	QueryOutputModeHTML
+	QueryOutputModeJSONL
+	QueryOutputModeYAML
 )
 
 // steampipe snapshot
@@ -26,6 +32,12 @@ var QueryOutputModeIds = map[QueryOutputMode][]string{
 	QueryOutputModeSnapshot:      {constants.OutputFormatSnapshot},
 	QueryOutputModeSnapshotShort: {OutputFormatSpSnapshotShort},
 	QueryOutputModeTable:         {constants.OutputFormatTable},
+	QueryOutputModeParquet:       {OutputFormatParquet},
+	QueryOutputModeArrow:         {OutputFormatArrow},
+	QueryOutputModeMd:            {constants.OutputFormatMD},
+	QueryOutputModeHTML:          {constants.OutputFormatHTML},
+	QueryOutputModeJSONL:         {OutputFormatJSONL},
+	QueryOutputModeYAML:          {OutputFormatYAML},
 }
 
 type QueryTimingMode enumflag.Flag
@@ -56,6 +68,32 @@ var QueryTimingValueLookup = map[string]struct{}{
 	"false":              {},
 }
 
+type QueryExplainMode enumflag.Flag
+
+const (
+	QueryExplainModeOff QueryExplainMode = iota
+	QueryExplainModeOn
+	QueryExplainModeAnalyze
+)
+
+var QueryExplainModeIds = map[QueryExplainMode][]string{
+	QueryExplainModeOff:     {constants.ArgOff},
+	QueryExplainModeOn:      {constants.ArgOn},
+	QueryExplainModeAnalyze: {ArgAnalyze},
+}
+
+type QueryOnErrorMode enumflag.Flag
+
+const (
+	QueryOnErrorModeContinue QueryOnErrorMode = iota
+	QueryOnErrorModeStop
+)
+
+var QueryOnErrorModeIds = map[QueryOnErrorMode][]string{
+	QueryOnErrorModeContinue: {ArgContinue},
+	QueryOnErrorModeStop:     {ArgStop},
+}
+
 type CheckTimingMode enumflag.Flag
 
 const (
@@ -81,6 +119,7 @@ const (
 	CheckOutputModeCsv
 	CheckOutputModeHTML
 	CheckOutputModeJSON
+	CheckOutputModeJUnit
 	CheckOutputModeMd
 	CheckOutputModeSnapshot
 	CheckOutputModeSnapshotShort
@@ -93,12 +132,27 @@ var CheckOutputModeIds = map[CheckOutputMode][]string{
 	CheckOutputModeCsv:           {constants.OutputFormatCSV},
 	CheckOutputModeHTML:          {constants.OutputFormatHTML},
 	CheckOutputModeJSON:          {constants.OutputFormatJSON},
+	CheckOutputModeJUnit:         {OutputFormatJUnit},
 	CheckOutputModeMd:            {constants.OutputFormatMD},
 	CheckOutputModeSnapshot:      {constants.OutputFormatSnapshot},
 	CheckOutputModeSnapshotShort: {OutputFormatSpSnapshotShort},
 	CheckOutputModeNone:          {constants.OutputFormatNone},
 }
 
+type CheckDiffOutputMode enumflag.Flag
+
+const (
+	CheckDiffOutputModeTable CheckDiffOutputMode = iota
+	CheckDiffOutputModeJSON
+	CheckDiffOutputModeMd
+)
+
+var CheckDiffOutputModeIds = map[CheckDiffOutputMode][]string{
+	CheckDiffOutputModeTable: {constants.OutputFormatTable},
+	CheckDiffOutputModeJSON:  {constants.OutputFormatJSON},
+	CheckDiffOutputModeMd:    {constants.OutputFormatMD},
+}
+
 func FlagValues[T comparable](mappings map[T][]string) []string {
 	var res = make([]string, 0, len(mappings))
 	for _, v := range mappings {
@@ -2,6 +2,8 @@ package constants
 
 // Constants for History
 const (
-	HistoryFile = "history.json" // File to store historical data
+	HistoryFile = "history.json" // Legacy file to store historical data (history is now stored per workspace)
 	HistorySize = 500            // Number of historical records to store
+
+	ScheduleHistorySize = 100 // Number of runs to store in the history of each schedule
 )
@@ -22,6 +22,18 @@ const (
 	CmdCache            = ".cache"              // cache control
 	CmdCacheTtl         = ".cache_ttl"          // set cache ttl
 	CmdAutoComplete     = ".autocomplete"       // enable or disable auto complete
+	CmdHistory          = ".history"            // list or search query history
+	CmdExplain          = ".explain"            // show the query plan
+	CmdSave             = ".save"               // save the last query to the workspace
+	CmdSaved            = ".saved"              // list the saved queries
+	CmdRun              = ".run"                // run a saved query
+	CmdSet              = ".set"                // set or list query parameters
+	CmdUnset            = ".unset"              // unset a query parameter
+	CmdOut              = ".out"                // write query results to a file
+	CmdOnce             = ".once"               // write the next query result to a file
+	CmdLast             = ".last"               // re-display a previous query result
+	CmdPage             = ".page"               // re-display a previous query result in the pager
+	CmdWatch            = ".watch"              // re-run a query on an interval
 )
 
 // ArgFromMetaquery converts a metaquery of form '.header' into the config argument used to set the mode, i.e. 'header'
@@ -10,4 +10,24 @@ const (
 	OutputFormatBrief         = "brief"
 	OutputFormatSnapshot      = "snapshot"
 	OutputFormatSnapshotShort = "sps"
+	OutputFormatSarif         = "sarif"
+	OutputFormatJUnit         = "junit"
+	OutputFormatMD            = "md"
+	OutputFormatHTML          = "html"
+	OutputFormatJSONL         = "jsonl"
+	OutputFormatYAML          = "yaml"
+	OutputFormatParquet       = "parquet"
+	OutputFormatArrow         = "arrow"
 )
+
+// MachineReadableOutputFormats is the list of output formats which must not be interleaved with messages and warnings
+var MachineReadableOutputFormats = []string{
+	OutputFormatJSON,
+	OutputFormatCSV,
+	OutputFormatParquet,
+	OutputFormatArrow,
+	OutputFormatMD,
+	OutputFormatHTML,
+	OutputFormatJSONL,
+	OutputFormatYAML,
+}
@@ -7,4 +7,8 @@ const (
 	ServerCert    = "server.crt"
 	RootCert      = "root.crt"
 	SslConfDir    = "/etc/ssl"
+
+	// the self-signed certificate used to serve the dashboard over https
+	DashboardCert    = "dashboard.crt"
+	DashboardCertKey = "dashboard.key"
 )
@@ -0,0 +1,220 @@
+package controldiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// CheckResults is the set of control results loaded from an exported check run
+type CheckResults struct {
+	Path string
+	// the mod the check was run in, if it can be determined from the results
+	RootMod string
+	// map of control results, keyed by the full control name
+	Controls map[string]*ControlResults
+}
+
+// ControlResults contains the status summary and result rows for a single control
+type ControlResults struct {
+	ControlId string
+	Title     string
+	Summary   controlstatus.StatusSummary
+	// map of result rows, keyed by controlexecute.ResultRowKey
+	Rows map[string]*ResultRow
+}
+
+// ResultRow is a single control result row
+type ResultRow struct {
+	Reason     string                     `json:"reason"`
+	Resource   string                     `json:"resource"`
+	Status     string                     `json:"status"`
+	Dimensions []controlexecute.Dimension `json:"dimensions"`
+}
+
+// LoadCheckResults loads the control results from either a check JSON export or a snapshot (.sps) file
+func LoadCheckResults(path string) (*CheckResults, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read '%s': %s", path, err.Error())
+	}
+
+	res := &CheckResults{
+		Path:     path,
+		Controls: make(map[string]*ControlResults),
+	}
+	if filepath.Ext(path) == constants.SnapshotExtension {
+		err = res.loadSnapshot(data)
+	} else {
+		err = res.loadJsonExport(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse '%s' - file must be a check JSON export or a snapshot: %s", path, err.Error())
+	}
+	return res, nil
+}
+
+// the structure of the json export format - only the properties required for the diff are parsed
+type jsonExportGroup struct {
+	GroupId  string               `json:"group_id"`
+	Groups   []*jsonExportGroup   `json:"groups"`
+	Controls []*jsonExportControl `json:"controls"`
+}
+
+type jsonExportControl struct {
+	ControlId string                      `json:"control_id"`
+	Title     string                      `json:"title"`
+	Summary   controlstatus.StatusSummary `json:"summary"`
+	Results   []*ResultRow                `json:"results"`
+}
+
+func (r *CheckResults) loadJsonExport(data []byte) error {
+	var root jsonExportGroup
+	if err := json.Unmarshal(data, &root); err != nil {
+		return err
+	}
+	// json exports use the unqualified name for controls in the workspace mod - these can only be children
+	// of a benchmark (or the mod) of the workspace mod, so use the first such group to find the workspace mod
+	r.RootMod = jsonExportWorkspaceMod(&root)
+	r.addJsonExportGroup(&root)
+	return nil
+}
+
+func jsonExportWorkspaceMod(group *jsonExportGroup) string {
+	for _, c := range group.Controls {
+		if resourceMod(c.ControlId) == "" {
+			return resourceMod(group.GroupId)
+		}
+	}
+	for _, child := range group.Groups {
+		if mod := jsonExportWorkspaceMod(child); mod != "" {
+			return mod
+		}
+	}
+	return ""
+}
+
+func (r *CheckResults) addJsonExportGroup(group *jsonExportGroup) {
+	for _, c := range group.Controls {
+		controlId := c.ControlId
+		if resourceMod(controlId) == "" && r.RootMod != "" {
+			controlId = fmt.Sprintf("%s.%s", r.RootMod, controlId)
+		}
+		control := r.getOrAddControl(controlId, c.Title, c.Summary)
+		for _, row := range c.Results {
+			control.addRow(row)
+		}
+	}
+	for _, child := range group.Groups {
+		r.addJsonExportGroup(child)
+	}
+}
+
+// the structure of a control panel in a snapshot - only the properties required for the diff are parsed
+type snapshotPanel struct {
+	Name      string                      `json:"name"`
+	Title     string                      `json:"title"`
+	PanelType string                      `json:"panel_type"`
+	Summary   controlstatus.StatusSummary `json:"summary"`
+	Data      *struct {
+		Rows []map[string]interface{} `json:"rows"`
+	} `json:"data"`
+}
+
+func (r *CheckResults) loadSnapshot(data []byte) error {
+	var snapshot struct {
+		Layout *struct {
+			Name string `json:"name"`
+		} `json:"layout"`
+		Panels map[string]*snapshotPanel `json:"panels"`
+	}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	// snapshots use the full control name, so the root mod is only used to display the control names
+	if snapshot.Layout != nil {
+		r.RootMod = resourceMod(snapshot.Layout.Name)
+	}
+	for _, panel := range snapshot.Panels {
+		if panel.PanelType != modconfig.BlockTypeControl {
+			continue
+		}
+		control := r.getOrAddControl(panel.Name, panel.Title, panel.Summary)
+		if panel.Data == nil {
+			continue
+		}
+		for _, data := range panel.Data.Rows {
+			row := &ResultRow{}
+			for k, v := range data {
+				value := typehelpers.ToString(v)
+				switch k {
+				case "reason":
+					row.Reason = value
+				case "resource":
+					row.Resource = value
+				case "status":
+					row.Status = value
+				default:
+					row.Dimensions = append(row.Dimensions, controlexecute.Dimension{Key: k, Value: value})
+				}
+			}
+			// map iteration order is random - sort the dimensions by key
+			sort.Slice(row.Dimensions, func(i, j int) bool { return row.Dimensions[i].Key < row.Dimensions[j].Key })
+			control.addRow(row)
+		}
+	}
+	return nil
+}
+
+func (r *CheckResults) getOrAddControl(controlId, title string, summary controlstatus.StatusSummary) *ControlResults {
+	// the same control may appear in more than one benchmark - only add the first instance
+	if control, ok := r.Controls[controlId]; ok {
+		return control
+	}
+	control := &ControlResults{
+		ControlId: controlId,
+		Title:     title,
+		Summary:   summary,
+		Rows:      make(map[string]*ResultRow),
+	}
+	r.Controls[controlId] = control
+	return control
+}
+
+func (c *ControlResults) addRow(row *ResultRow) {
+	c.Rows[controlexecute.ResultRowKey(c.ControlId, row.Resource, row.Dimensions)] = row
+}
+
+// resourceMod returns the mod of a full resource name (<mod>.<type>.<name> or mod.<mod>),
+// or an empty string if the name is not qualified
+func resourceMod(name string) string {
+	parts := strings.Split(name, ".")
+	switch {
+	case len(parts) == 3:
+		return parts[0]
+	case len(parts) == 2 && parts[0] == modconfig.BlockTypeMod:
+		return parts[1]
+	}
+	return ""
+}
+
+// displayControlId returns the control name to display in the diff - if both runs were made in the same
+// mod, controls of that mod are displayed with their unqualified name (control.<name>)
+func displayControlId(controlId string, before, after *CheckResults) string {
+	if before.RootMod == "" || before.RootMod != after.RootMod {
+		return controlId
+	}
+	if prefix := before.RootMod + "."; strings.HasPrefix(controlId, prefix) {
+		return strings.TrimPrefix(controlId, prefix)
+	}
+	return controlId
+}
@@ -0,0 +1,128 @@
+package controldiff
+
+import (
+	"sort"
+
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+// CheckDiff is the result of comparing two check runs
+type CheckDiff struct {
+	Before string `json:"before"`
+	After  string `json:"after"`
+	// controls whose status counts have changed
+	Controls []*ControlDiff `json:"controls"`
+	// resources whose status has changed
+	Transitions []*StatusTransition `json:"transitions"`
+}
+
+// ControlDiff contains the status summaries of a control in both runs
+// if the control is not present in one of the runs, the corresponding summary is nil
+type ControlDiff struct {
+	ControlId string                       `json:"control_id"`
+	Title     string                       `json:"title,omitempty"`
+	Before    *controlstatus.StatusSummary `json:"before"`
+	After     *controlstatus.StatusSummary `json:"after"`
+}
+
+// StatusTransition is the change in status of a single control result row
+// if the row is not present in one of the runs, the corresponding status is empty
+type StatusTransition struct {
+	ControlId  string                     `json:"control_id"`
+	Resource   string                     `json:"resource"`
+	Dimensions []controlexecute.Dimension `json:"dimensions,omitempty"`
+	Before     string                     `json:"before"`
+	After      string                     `json:"after"`
+	Reason     string                     `json:"reason,omitempty"`
+}
+
+// Diff compares two sets of check results
+func Diff(before, after *CheckResults) *CheckDiff {
+	res := &CheckDiff{
+		Before:      before.Path,
+		After:       after.Path,
+		Controls:    []*ControlDiff{},
+		Transitions: []*StatusTransition{},
+	}
+
+	for _, controlId := range controlIds(before, after) {
+		beforeControl := before.Controls[controlId]
+		afterControl := after.Controls[controlId]
+
+		displayId := displayControlId(controlId, before, after)
+		controlDiff := &ControlDiff{ControlId: displayId}
+		var beforeRows, afterRows map[string]*ResultRow
+		if beforeControl != nil {
+			controlDiff.Title = beforeControl.Title
+			controlDiff.Before = &beforeControl.Summary
+			beforeRows = beforeControl.Rows
+		}
+		if afterControl != nil {
+			controlDiff.Title = afterControl.Title
+			controlDiff.After = &afterControl.Summary
+			afterRows = afterControl.Rows
+		}
+		if controlDiff.Before == nil || controlDiff.After == nil || *controlDiff.Before != *controlDiff.After {
+			res.Controls = append(res.Controls, controlDiff)
+		}
+
+		res.Transitions = append(res.Transitions, diffRows(displayId, beforeRows, afterRows)...)
+	}
+	return res
+}
+
+func diffRows(controlId string, beforeRows, afterRows map[string]*ResultRow) []*StatusTransition {
+	var res []*StatusTransition
+	for _, key := range rowKeys(beforeRows, afterRows) {
+		beforeRow := beforeRows[key]
+		afterRow := afterRows[key]
+
+		transition := &StatusTransition{ControlId: controlId}
+		if beforeRow != nil {
+			transition.Resource = beforeRow.Resource
+			transition.Dimensions = beforeRow.Dimensions
+			transition.Before = beforeRow.Status
+		}
+		if afterRow != nil {
+			transition.Resource = afterRow.Resource
+			transition.Dimensions = afterRow.Dimensions
+			transition.After = afterRow.Status
+			transition.Reason = afterRow.Reason
+		}
+		if transition.Before != transition.After {
+			res = append(res, transition)
+		}
+	}
+	return res
+}
+
+// controlIds returns the sorted union of the control ids of both results
+func controlIds(before, after *CheckResults) []string {
+	var res []string
+	for id := range before.Controls {
+		res = append(res, id)
+	}
+	for id := range after.Controls {
+		if _, ok := before.Controls[id]; !ok {
+			res = append(res, id)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
+
+// rowKeys returns the sorted union of the row keys of both maps
+func rowKeys(before, after map[string]*ResultRow) []string {
+	var res []string
+	for key := range before {
+		res = append(res, key)
+	}
+	for key := range after {
+		if _, ok := before[key]; !ok {
+			res = append(res, key)
+		}
+	}
+	sort.Strings(res)
+	return res
+}
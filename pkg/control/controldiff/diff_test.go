@@ -0,0 +1,144 @@
+package controldiff
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+func newTestResults(path string, controls ...*ControlResults) *CheckResults {
+	res := &CheckResults{Path: path, Controls: make(map[string]*ControlResults)}
+	for _, c := range controls {
+		res.Controls[c.ControlId] = c
+	}
+	return res
+}
+
+func newTestControl(controlId string, summary controlstatus.StatusSummary, rows ...*ResultRow) *ControlResults {
+	res := &ControlResults{ControlId: controlId, Summary: summary, Rows: make(map[string]*ResultRow)}
+	for _, row := range rows {
+		res.addRow(row)
+	}
+	return res
+}
+
+func TestDiff(t *testing.T) {
+	before := newTestResults("before.json",
+		newTestControl("control.c1", controlstatus.StatusSummary{Ok: 2},
+			&ResultRow{Resource: "r1", Status: "ok"},
+			&ResultRow{Resource: "r2", Status: "ok"},
+		),
+		newTestControl("control.unchanged", controlstatus.StatusSummary{Alarm: 1},
+			&ResultRow{Resource: "r1", Status: "alarm"},
+		),
+		newTestControl("control.removed", controlstatus.StatusSummary{Ok: 1},
+			&ResultRow{Resource: "r1", Status: "ok"},
+		),
+	)
+	after := newTestResults("after.json",
+		newTestControl("control.c1", controlstatus.StatusSummary{Ok: 1, Alarm: 1},
+			&ResultRow{Resource: "r1", Status: "alarm"},
+			&ResultRow{Resource: "r2", Status: "ok"},
+		),
+		newTestControl("control.unchanged", controlstatus.StatusSummary{Alarm: 1},
+			&ResultRow{Resource: "r1", Status: "alarm"},
+		),
+	)
+
+	diff := Diff(before, after)
+
+	if len(diff.Controls) != 2 {
+		t.Fatalf("expected 2 changed controls, got %d", len(diff.Controls))
+	}
+	if diff.Controls[0].ControlId != "control.c1" || diff.Controls[1].ControlId != "control.removed" {
+		t.Errorf("unexpected changed controls: %s, %s", diff.Controls[0].ControlId, diff.Controls[1].ControlId)
+	}
+	if diff.Controls[1].After != nil {
+		t.Errorf("expected removed control to have no 'after' summary")
+	}
+
+	expectedTransitions := []string{"control.c1 r1 ok→alarm", "control.removed r1 ok→-"}
+	if len(diff.Transitions) != len(expectedTransitions) {
+		t.Fatalf("expected %d transitions, got %d", len(expectedTransitions), len(diff.Transitions))
+	}
+	for i, expected := range expectedTransitions {
+		tr := diff.Transitions[i]
+		if actual := tr.ControlId + " " + tr.Resource + " " + tr.transitionString(); actual != expected {
+			t.Errorf("expected transition '%s', got '%s'", expected, actual)
+		}
+	}
+}
+
+func writeTestFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadCheckResultsControlIds(t *testing.T) {
+	jsonExport := writeTestFile(t, "run.json", `{
+	"group_id": "root_result_group",
+	"groups": [{
+		"group_id": "local.benchmark.b1",
+		"controls": [
+			{"control_id": "control.c1", "results": [{"resource": "r1", "status": "ok"}]},
+			{"control_id": "dep.control.c1", "results": [{"resource": "r1", "status": "alarm"}]}
+		]
+	}]
+}`)
+	snapshot := writeTestFile(t, "run.sps", `{
+	"layout": {"name": "local.benchmark.b1"},
+	"panels": {
+		"local.control.c1": {"name": "local.control.c1", "panel_type": "control", "data": {"rows": [{"resource": "r1", "status": "alarm"}]}},
+		"dep.control.c1": {"name": "dep.control.c1", "panel_type": "control", "data": {"rows": [{"resource": "r1", "status": "alarm"}]}}
+	}
+}`)
+
+	before, err := LoadCheckResults(jsonExport)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after, err := LoadCheckResults(snapshot)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// controls with the same short name in different mods must not be merged
+	for _, results := range []*CheckResults{before, after} {
+		if results.RootMod != "local" {
+			t.Errorf("%s: expected root mod 'local', got '%s'", results.Path, results.RootMod)
+		}
+		if len(results.Controls) != 2 || results.Controls["local.control.c1"] == nil || results.Controls["dep.control.c1"] == nil {
+			t.Errorf("%s: expected controls local.control.c1 and dep.control.c1, got %v", results.Path, results.Controls)
+		}
+	}
+
+	// both runs were made in the same mod, so its controls are displayed unqualified
+	diff := Diff(before, after)
+	if len(diff.Transitions) != 1 || diff.Transitions[0].ControlId != "control.c1" {
+		t.Errorf("expected a single transition of control.c1, got %v", diff.Transitions)
+	}
+}
+
+func TestDisplayControlId(t *testing.T) {
+	cases := map[string]struct {
+		beforeMod string
+		afterMod  string
+		expected  string
+	}{
+		"same root mod":      {beforeMod: "local", afterMod: "local", expected: "control.c1"},
+		"different root mod": {beforeMod: "local", afterMod: "other", expected: "local.control.c1"},
+		"unknown root mod":   {beforeMod: "", afterMod: "", expected: "local.control.c1"},
+	}
+	for name, test := range cases {
+		before := &CheckResults{RootMod: test.beforeMod}
+		after := &CheckResults{RootMod: test.afterMod}
+		if actual := displayControlId("local.control.c1", before, after); actual != test.expected {
+			t.Errorf("%s: expected %s, got %s", name, test.expected, actual)
+		}
+	}
+}
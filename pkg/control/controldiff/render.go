@@ -0,0 +1,105 @@
+package controldiff
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/control/controlstatus"
+)
+
+// absent is displayed in place of a status or summary which is not present in a run
+const absent = "-"
+
+// ControlTable returns the headers and rows of a table of changed controls
+func (d *CheckDiff) ControlTable() ([]string, [][]string) {
+	headers := []string{"Control", "Before", "After"}
+	rows := make([][]string, len(d.Controls))
+	for i, c := range d.Controls {
+		rows[i] = []string{c.ControlId, summaryString(c.Before), summaryString(c.After)}
+	}
+	return headers, rows
+}
+
+// TransitionTable returns the headers and rows of a table of resource status transitions
+func (d *CheckDiff) TransitionTable() ([]string, [][]string) {
+	headers := []string{"Control", "Resource", "Dimensions", "Transition", "Reason"}
+	rows := make([][]string, len(d.Transitions))
+	for i, t := range d.Transitions {
+		rows[i] = []string{t.ControlId, t.Resource, dimensionsString(t.Dimensions), t.transitionString(), t.Reason}
+	}
+	return headers, rows
+}
+
+// Json returns the diff as indented json
+func (d *CheckDiff) Json() (string, error) {
+	res, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(res), nil
+}
+
+// Markdown returns the diff as markdown tables
+func (d *CheckDiff) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Check diff\n\n")
+	fmt.Fprintf(&b, "Before: `%s`\n\nAfter: `%s`\n\n", d.Before, d.After)
+
+	fmt.Fprintf(&b, "## Controls\n\n")
+	if len(d.Controls) == 0 {
+		fmt.Fprintf(&b, "No controls changed.\n\n")
+	} else {
+		headers, rows := d.ControlTable()
+		writeMarkdownTable(&b, headers, rows)
+	}
+
+	fmt.Fprintf(&b, "## Resources\n\n")
+	if len(d.Transitions) == 0 {
+		fmt.Fprintf(&b, "No resource status changes.\n")
+	} else {
+		headers, rows := d.TransitionTable()
+		writeMarkdownTable(&b, headers, rows)
+	}
+	return b.String()
+}
+
+func writeMarkdownTable(b *strings.Builder, headers []string, rows [][]string) {
+	fmt.Fprintf(b, "| %s |\n", strings.Join(headers, " | "))
+	fmt.Fprintf(b, "|%s\n", strings.Repeat(" --- |", len(headers)))
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, v := range row {
+			escaped[i] = strings.ReplaceAll(v, "|", "\\|")
+		}
+		fmt.Fprintf(b, "| %s |\n", strings.Join(escaped, " | "))
+	}
+	b.WriteString("\n")
+}
+
+func (t *StatusTransition) transitionString() string {
+	before, after := t.Before, t.After
+	if before == "" {
+		before = absent
+	}
+	if after == "" {
+		after = absent
+	}
+	return fmt.Sprintf("%s→%s", before, after)
+}
+
+func summaryString(s *controlstatus.StatusSummary) string {
+	if s == nil {
+		return absent
+	}
+	return fmt.Sprintf("ok:%d alarm:%d error:%d info:%d skip:%d suppressed:%d", s.Ok, s.Alarm, s.Error, s.Info, s.Skip, s.Suppressed)
+}
+
+func dimensionsString(dimensions []controlexecute.Dimension) string {
+	res := make([]string, len(dimensions))
+	for i, d := range dimensions {
+		res[i] = fmt.Sprintf("%s=%s", d.Key, d.Value)
+	}
+	return strings.Join(res, ", ")
+}
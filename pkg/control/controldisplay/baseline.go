@@ -0,0 +1,54 @@
+package controldisplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/export"
+)
+
+// ExportBaseline writes the results of the given execution trees to a baseline file, using the json output format
+// if there is more than one tree, the trees are written as child groups of a single parent group
+func ExportBaseline(ctx context.Context, filePath string, trees ...*controlexecute.ExecutionTree) error {
+	formatResolver, err := NewFormatResolver(ctx)
+	if err != nil {
+		return err
+	}
+	formatter, err := formatResolver.GetFormatter(constants.OutputFormatJSON)
+	if err != nil {
+		return err
+	}
+
+	exportCtx := context.WithValue(ctx, contextKeyFormatterPurpose, formatterPurposeExport)
+	var groups []json.RawMessage
+	for _, tree := range trees {
+		reader, err := formatter.Format(exportCtx, tree)
+		if err != nil {
+			return err
+		}
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return err
+		}
+		groups = append(groups, data)
+	}
+
+	var res []byte
+	if len(groups) == 1 {
+		res = groups[0]
+	} else {
+		res, err = json.MarshalIndent(map[string]interface{}{
+			"group_id": controlexecute.RootResultGroupName,
+			"groups":   groups,
+			"controls": nil,
+		}, "", "  ")
+		if err != nil {
+			return err
+		}
+	}
+	return export.Write(filePath, bytes.NewReader(res))
+}
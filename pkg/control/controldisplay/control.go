@@ -74,8 +74,14 @@ func (r ControlRenderer) postResultIndent() string {
 
 func (r ControlRenderer) Render() string {
 	var controlStrings []string
+	title := typehelpers.SafeString(r.run.Control.Title)
+	// if the control succeeded after being retried, show the number of attempts
+	// (for a failed control, this is included in the error)
+	if r.run.Attempts > 1 && r.run.GetError() == nil {
+		title = fmt.Sprintf("%s (%d attempts)", title, r.run.Attempts)
+	}
 	// use group heading renderer to render the control title and counts
-	controlHeadingRenderer := NewGroupHeadingRenderer(typehelpers.SafeString(r.run.Control.Title),
+	controlHeadingRenderer := NewGroupHeadingRenderer(title,
 		r.run.Summary.FailedCount(),
 		r.run.Summary.TotalCount(),
 		r.maxFailedControls,
@@ -26,6 +26,8 @@ func NewFormatResolver(ctx context.Context) (*FormatResolver, error) {
 		&NullFormatter{},
 		&TextFormatter{},
 		&SnapshotFormatter{},
+		&SarifFormatter{},
+		&JUnitFormatter{},
 	}
 
 	res := &FormatResolver{
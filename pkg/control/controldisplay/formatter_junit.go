@@ -0,0 +1,226 @@
+package controldisplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+)
+
+// JUnitFormatter implements the 'Formatter' interface and renders the execution tree as JUnit XML
+// benchmarks are mapped to nested testsuites, and controls are mapped to testsuites containing a testcase for each result row
+type JUnitFormatter struct{}
+
+func (f *JUnitFormatter) Format(ctx context.Context, tree *controlexecute.ExecutionTree) (io.Reader, error) {
+	suites := newJUnitTestSuites(tree)
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return nil, err
+	}
+	buf.WriteString("\n")
+	return &buf, nil
+}
+
+func (f *JUnitFormatter) FileExtension() string {
+	return constants.JUnitExtension
+}
+
+func (f *JUnitFormatter) Name() string {
+	return constants.OutputFormatJUnit
+}
+
+func (f *JUnitFormatter) Alias() string {
+	return "junit.xml"
+}
+
+type jUnitTestSuites struct {
+	XMLName    xml.Name          `xml:"testsuites"`
+	Name       string            `xml:"name,attr"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Errors     int               `xml:"errors,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	Time       string            `xml:"time,attr"`
+	Timestamp  string            `xml:"timestamp,attr,omitempty"`
+	TestSuites []*jUnitTestSuite `xml:"testsuite"`
+}
+
+type jUnitTestSuite struct {
+	Name       string            `xml:"name,attr"`
+	Tests      int               `xml:"tests,attr"`
+	Failures   int               `xml:"failures,attr"`
+	Errors     int               `xml:"errors,attr"`
+	Skipped    int               `xml:"skipped,attr"`
+	Time       string            `xml:"time,attr"`
+	Properties *jUnitProperties  `xml:"properties,omitempty"`
+	TestSuites []*jUnitTestSuite `xml:"testsuite"`
+	TestCases  []*jUnitTestCase  `xml:"testcase"`
+}
+
+type jUnitProperties struct {
+	Properties []jUnitProperty `xml:"property"`
+}
+
+type jUnitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type jUnitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *jUnitResult  `xml:"failure,omitempty"`
+	Error     *jUnitResult  `xml:"error,omitempty"`
+	Skipped   *jUnitSkipped `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type jUnitResult struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type jUnitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+func newJUnitTestSuites(tree *controlexecute.ExecutionTree) *jUnitTestSuites {
+	root := newJUnitGroupTestSuite(tree.Root)
+	res := &jUnitTestSuites{
+		Name:       tree.Root.Title,
+		Tests:      root.Tests,
+		Failures:   root.Failures,
+		Errors:     root.Errors,
+		Skipped:    root.Skipped,
+		Time:       jUnitDuration(tree.EndTime.Sub(tree.StartTime)),
+		TestSuites: root.TestSuites,
+	}
+	if !tree.StartTime.IsZero() {
+		res.Timestamp = tree.StartTime.UTC().Format(time.RFC3339)
+	}
+	return res
+}
+
+// newJUnitGroupTestSuite converts a result group (and all of its descendants) into a testsuite
+func newJUnitGroupTestSuite(group *controlexecute.ResultGroup) *jUnitTestSuite {
+	suite := &jUnitTestSuite{
+		Name: group.GroupId,
+		Time: jUnitDuration(group.Duration),
+	}
+	// iterate through the children to preserve the order of the benchmark hierarchy
+	for _, child := range group.Children {
+		var childSuite *jUnitTestSuite
+		switch c := child.(type) {
+		case *controlexecute.ResultGroup:
+			childSuite = newJUnitGroupTestSuite(c)
+		case *controlexecute.ControlRun:
+			childSuite = newJUnitControlTestSuite(c)
+		default:
+			continue
+		}
+		suite.TestSuites = append(suite.TestSuites, childSuite)
+		suite.Tests += childSuite.Tests
+		suite.Failures += childSuite.Failures
+		suite.Errors += childSuite.Errors
+		suite.Skipped += childSuite.Skipped
+	}
+	return suite
+}
+
+// newJUnitControlTestSuite converts a control run into a testsuite with a testcase for each result row
+// if the control run failed, a single testcase is added containing the error
+func newJUnitControlTestSuite(run *controlexecute.ControlRun) *jUnitTestSuite {
+	suite := &jUnitTestSuite{
+		Name:       run.FullName,
+		Time:       jUnitDuration(run.Duration),
+		Properties: newJUnitControlProperties(run),
+	}
+
+	if run.RunErrorString != "" {
+		suite.TestCases = []*jUnitTestCase{{
+			Name:      run.FullName,
+			ClassName: run.FullName,
+			Time:      jUnitDuration(run.Duration),
+			Error:     &jUnitResult{Message: run.RunErrorString, Type: constants.ControlError},
+		}}
+		suite.Tests = 1
+		suite.Errors = 1
+		return suite
+	}
+
+	// apportion the control duration between the rows so the testcase times add up to the control time
+	var rowDuration time.Duration
+	if rowCount := len(run.Rows); rowCount > 0 {
+		rowDuration = run.Duration / time.Duration(rowCount)
+	}
+	for _, row := range run.Rows {
+		suite.TestCases = append(suite.TestCases, newJUnitTestCase(row, rowDuration))
+	}
+	suite.Tests = len(suite.TestCases)
+	suite.Failures = run.Summary.FailedCount()
+	suite.Skipped = run.Summary.SkippedCount()
+	return suite
+}
+
+func newJUnitTestCase(row *controlexecute.ResultRow, duration time.Duration) *jUnitTestCase {
+	testCase := &jUnitTestCase{
+		Name:      row.Resource,
+		ClassName: row.Run.FullName,
+		Time:      jUnitDuration(duration),
+	}
+	switch row.Status {
+	case constants.ControlAlarm, constants.ControlError:
+		testCase.Failure = &jUnitResult{
+			Message: row.Reason,
+			Type:    row.Status,
+			Text:    jUnitDimensionsText(row),
+		}
+	case constants.ControlSkip, constants.ControlSuppressed:
+		testCase.Skipped = &jUnitSkipped{Message: row.Reason}
+	default:
+		testCase.SystemOut = row.Reason
+	}
+	return testCase
+}
+
+func newJUnitControlProperties(run *controlexecute.ControlRun) *jUnitProperties {
+	var properties []jUnitProperty
+	if run.Title != "" {
+		properties = append(properties, jUnitProperty{Name: "title", Value: run.Title})
+	}
+	if run.Severity != "" {
+		properties = append(properties, jUnitProperty{Name: "severity", Value: run.Severity})
+	}
+	if len(properties) == 0 {
+		return nil
+	}
+	return &jUnitProperties{Properties: properties}
+}
+
+// jUnitDimensionsText returns the row dimensions as 'key: value' lines
+func jUnitDimensionsText(row *controlexecute.ResultRow) string {
+	var buf bytes.Buffer
+	for _, dim := range row.Dimensions {
+		fmt.Fprintf(&buf, "%s: %s\n", dim.Key, dim.Value)
+	}
+	return buf.String()
+}
+
+// jUnitDuration formats a duration as seconds, as expected by JUnit consumers
+func jUnitDuration(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
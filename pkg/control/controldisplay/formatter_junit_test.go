@@ -0,0 +1,53 @@
+package controldisplay
+
+import (
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+)
+
+type jUnitTestCaseTest struct {
+	status          string
+	expectFailure   bool
+	expectSkipped   bool
+	expectSystemOut bool
+}
+
+func testCasesJUnitTestCase() map[string]jUnitTestCaseTest {
+	return map[string]jUnitTestCaseTest{
+		"alarm": {status: "alarm", expectFailure: true},
+		"error": {status: "error", expectFailure: true},
+		"skip":  {status: "skip", expectSkipped: true},
+		"ok":    {status: "ok", expectSystemOut: true},
+		"info":  {status: "info", expectSystemOut: true},
+	}
+}
+
+func TestJUnitTestCase(t *testing.T) {
+	run := &controlexecute.ControlRun{FullName: "mod.control.c1"}
+	for name, test := range testCasesJUnitTestCase() {
+		row := &controlexecute.ResultRow{
+			Status:   test.status,
+			Reason:   "reason",
+			Resource: "resource",
+			Run:      run,
+		}
+		testCase := newJUnitTestCase(row, 1500*time.Millisecond)
+		if testCase.ClassName != run.FullName || testCase.Name != row.Resource {
+			t.Errorf("Test: '%s'' FAILED : unexpected testcase name '%s.%s'", name, testCase.ClassName, testCase.Name)
+		}
+		if testCase.Time != "1.500" {
+			t.Errorf("Test: '%s'' FAILED : expected time '1.500', got '%s'", name, testCase.Time)
+		}
+		if (testCase.Failure != nil) != test.expectFailure {
+			t.Errorf("Test: '%s'' FAILED : expected failure %v", name, test.expectFailure)
+		}
+		if (testCase.Skipped != nil) != test.expectSkipped {
+			t.Errorf("Test: '%s'' FAILED : expected skipped %v", name, test.expectSkipped)
+		}
+		if (testCase.SystemOut != "") != test.expectSystemOut {
+			t.Errorf("Test: '%s'' FAILED : expected system-out %v", name, test.expectSystemOut)
+		}
+	}
+}
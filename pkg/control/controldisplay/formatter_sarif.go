@@ -0,0 +1,363 @@
+package controldisplay
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/version"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SarifFormatter implements the 'Formatter' interface and renders the execution tree as a SARIF 2.1.0 log
+// each control is mapped to a SARIF rule and each control result row to a SARIF result
+type SarifFormatter struct {
+	FormatterBase
+}
+
+func (f *SarifFormatter) Format(ctx context.Context, tree *controlexecute.ExecutionTree) (io.Reader, error) {
+	log := newSarifLog(tree)
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(log); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}
+
+func (f *SarifFormatter) FileExtension() string {
+	return constants.SarifExtension
+}
+
+func (f *SarifFormatter) Name() string {
+	return constants.OutputFormatSarif
+}
+
+type sarifLog struct {
+	Version string      `json:"version"`
+	Schema  string      `json:"$schema"`
+	Runs    []*sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool        sarifTool          `json:"tool"`
+	Invocations []*sarifInvocation `json:"invocations,omitempty"`
+	Results     []*sarifResult     `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string       `json:"name"`
+	Version        string       `json:"version"`
+	InformationUri string       `json:"informationUri"`
+	Rules          []*sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	Id                   string                 `json:"id"`
+	Name                 string                 `json:"name,omitempty"`
+	ShortDescription     *sarifMessage          `json:"shortDescription,omitempty"`
+	FullDescription      *sarifMessage          `json:"fullDescription,omitempty"`
+	Help                 *sarifMessage          `json:"help,omitempty"`
+	DefaultConfiguration *sarifRuleConfig       `json:"defaultConfiguration,omitempty"`
+	Properties           map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifRuleConfig struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text     string `json:"text"`
+	Markdown string `json:"markdown,omitempty"`
+}
+
+type sarifInvocation struct {
+	ExecutionSuccessful        bool                 `json:"executionSuccessful"`
+	StartTimeUtc               string               `json:"startTimeUtc,omitempty"`
+	EndTimeUtc                 string               `json:"endTimeUtc,omitempty"`
+	ToolExecutionNotifications []*sarifNotification `json:"toolExecutionNotifications,omitempty"`
+}
+
+type sarifNotification struct {
+	Level      string              `json:"level"`
+	Message    sarifMessage        `json:"message"`
+	Descriptor *sarifDescriptorRef `json:"associatedRule,omitempty"`
+	Locations  []*sarifLocation    `json:"locations,omitempty"`
+}
+
+type sarifDescriptorRef struct {
+	Id    string `json:"id"`
+	Index int    `json:"index"`
+}
+
+type sarifResult struct {
+	RuleId       string                 `json:"ruleId"`
+	RuleIndex    int                    `json:"ruleIndex"`
+	Kind         string                 `json:"kind"`
+	Level        string                 `json:"level"`
+	Message      sarifMessage           `json:"message"`
+	Locations    []*sarifLocation       `json:"locations,omitempty"`
+	Suppressions []*sarifSuppression    `json:"suppressions,omitempty"`
+	Properties   map[string]interface{} `json:"properties,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation  `json:"physicalLocation,omitempty"`
+	LogicalLocations []*sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	Uri string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+func newSarifLog(tree *controlexecute.ExecutionTree) *sarifLog {
+	run := &sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "Steampipe",
+				Version:        version.SteampipeVersion.String(),
+				InformationUri: "https://steampipe.io",
+				Rules:          []*sarifRule{},
+			},
+		},
+		Results: []*sarifResult{},
+	}
+	invocation := &sarifInvocation{ExecutionSuccessful: true}
+	if !tree.StartTime.IsZero() {
+		invocation.StartTimeUtc = tree.StartTime.UTC().Format(sarifTimeFormat)
+	}
+	if !tree.EndTime.IsZero() {
+		invocation.EndTimeUtc = tree.EndTime.UTC().Format(sarifTimeFormat)
+	}
+
+	// the same control may appear in more than one benchmark - only add a single rule for each control
+	ruleIndexes := make(map[string]int)
+	for _, controlRun := range tree.ControlRuns {
+		ruleIndex, ok := ruleIndexes[controlRun.FullName]
+		if !ok {
+			ruleIndex = len(run.Tool.Driver.Rules)
+			ruleIndexes[controlRun.FullName] = ruleIndex
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, newSarifRule(controlRun))
+		}
+
+		controlLocation := sarifControlLocation(tree, controlRun)
+
+		// a control run error means the control could not be evaluated - report as a tool notification
+		if controlRun.RunErrorString != "" {
+			invocation.ExecutionSuccessful = false
+			notification := &sarifNotification{
+				Level:      sarifLevelError,
+				Message:    sarifMessage{Text: controlRun.RunErrorString},
+				Descriptor: &sarifDescriptorRef{Id: controlRun.FullName, Index: ruleIndex},
+			}
+			if controlLocation != nil {
+				notification.Locations = []*sarifLocation{controlLocation}
+			}
+			invocation.ToolExecutionNotifications = append(invocation.ToolExecutionNotifications, notification)
+		}
+
+		for _, row := range controlRun.Rows {
+			run.Results = append(run.Results, newSarifResult(row, ruleIndex, controlLocation))
+		}
+	}
+
+	run.Invocations = []*sarifInvocation{invocation}
+	return &sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []*sarifRun{run},
+	}
+}
+
+func newSarifRule(controlRun *controlexecute.ControlRun) *sarifRule {
+	rule := &sarifRule{
+		Id:   controlRun.FullName,
+		Name: controlRun.Control.ShortName,
+		DefaultConfiguration: &sarifRuleConfig{
+			Level: sarifLevelForSeverity(controlRun.Severity),
+		},
+	}
+	if controlRun.Title != "" {
+		rule.ShortDescription = &sarifMessage{Text: controlRun.Title}
+	}
+	if controlRun.Description != "" {
+		rule.FullDescription = &sarifMessage{Text: controlRun.Description}
+	}
+	if controlRun.Documentation != "" {
+		rule.Help = &sarifMessage{Text: controlRun.Documentation, Markdown: controlRun.Documentation}
+	}
+
+	properties := make(map[string]interface{})
+	if controlRun.Severity != "" {
+		properties["severity"] = controlRun.Severity
+	}
+	if len(controlRun.Tags) > 0 {
+		// code scanning tools expect 'tags' to be a list of strings
+		var tags []string
+		for k, v := range controlRun.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		properties["tags"] = tags
+	}
+	if len(properties) > 0 {
+		rule.Properties = properties
+	}
+	return rule
+}
+
+func newSarifResult(row *controlexecute.ResultRow, ruleIndex int, controlLocation *sarifLocation) *sarifResult {
+	res := &sarifResult{
+		RuleId:    row.Run.FullName,
+		RuleIndex: ruleIndex,
+		Kind:      sarifKindForStatus(row.Status),
+		Level:     sarifLevelForRow(row),
+		Message:   sarifMessage{Text: row.Reason},
+	}
+
+	location := &sarifLocation{}
+	if controlLocation != nil {
+		location.PhysicalLocation = controlLocation.PhysicalLocation
+	}
+	if row.Resource != "" {
+		location.LogicalLocations = []*sarifLogicalLocation{{FullyQualifiedName: row.Resource, Kind: "resource"}}
+	}
+	if location.PhysicalLocation != nil || location.LogicalLocations != nil {
+		res.Locations = []*sarifLocation{location}
+	}
+
+	// suppressed results are reported as failures with an external suppression (the exemption)
+	if row.Status == constants.ControlSuppressed {
+		res.Suppressions = []*sarifSuppression{{Kind: "external", Justification: row.Exemption}}
+	}
+
+	properties := map[string]interface{}{"status": row.Status}
+	// dimensions are nested so that they cannot overwrite the status
+	if len(row.Dimensions) > 0 {
+		dimensions := make(map[string]string, len(row.Dimensions))
+		for _, dim := range row.Dimensions {
+			dimensions[dim.Key] = dim.Value
+		}
+		properties["dimensions"] = dimensions
+	}
+	res.Properties = properties
+	return res
+}
+
+// sarifControlLocation returns the location of the control declaration, relative to the workspace
+func sarifControlLocation(tree *controlexecute.ExecutionTree, controlRun *controlexecute.ControlRun) *sarifLocation {
+	declRange := controlRun.Control.GetDeclRange()
+	if declRange == nil || declRange.Filename == "" {
+		return nil
+	}
+	uri := declRange.Filename
+	if tree.Workspace != nil {
+		if rel, err := filepath.Rel(tree.Workspace.Path, uri); err == nil && !strings.HasPrefix(rel, "..") {
+			uri = rel
+		}
+	}
+	return &sarifLocation{
+		PhysicalLocation: &sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{Uri: filepath.ToSlash(uri)},
+			Region: &sarifRegion{
+				StartLine:   declRange.Start.Line,
+				StartColumn: declRange.Start.Column,
+				EndLine:     declRange.End.Line,
+				EndColumn:   declRange.End.Column,
+			},
+		},
+	}
+}
+
+const (
+	sarifTimeFormat = "2006-01-02T15:04:05.000Z"
+
+	sarifLevelError   = "error"
+	sarifLevelWarning = "warning"
+	sarifLevelNote    = "note"
+	sarifLevelNone    = "none"
+
+	sarifKindFail          = "fail"
+	sarifKindPass          = "pass"
+	sarifKindInformational = "informational"
+	sarifKindNotApplicable = "notApplicable"
+)
+
+// sarifKindForStatus maps a control status to a SARIF result kind
+func sarifKindForStatus(status string) string {
+	switch status {
+	case constants.ControlOk:
+		return sarifKindPass
+	case constants.ControlInfo:
+		return sarifKindInformational
+	case constants.ControlSkip:
+		return sarifKindNotApplicable
+	default:
+		// alarm, error and suppressed
+		return sarifKindFail
+	}
+}
+
+// sarifLevelForRow returns the SARIF level for a result row
+// only failing results have a level - all other kinds must have a level of 'none'
+func sarifLevelForRow(row *controlexecute.ResultRow) string {
+	switch row.Status {
+	case constants.ControlAlarm, constants.ControlSuppressed:
+		return sarifLevelForSeverity(row.Run.Severity)
+	case constants.ControlError:
+		return sarifLevelError
+	default:
+		return sarifLevelNone
+	}
+}
+
+// sarifLevelForSeverity maps a control severity to a SARIF level
+func sarifLevelForSeverity(severity string) string {
+	switch strings.ToLower(severity) {
+	case "critical", "high":
+		return sarifLevelError
+	case "low":
+		return sarifLevelNote
+	default:
+		// medium, none and unset severities
+		return sarifLevelWarning
+	}
+}
@@ -0,0 +1,113 @@
+package controldisplay
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+)
+
+type sarifLevelTest struct {
+	status        string
+	severity      string
+	expectedKind  string
+	expectedLevel string
+}
+
+func testCasesSarifLevel() map[string]sarifLevelTest {
+	return map[string]sarifLevelTest{
+		"alarm critical": {
+			status:        "alarm",
+			severity:      "critical",
+			expectedKind:  "fail",
+			expectedLevel: "error",
+		},
+		"alarm high": {
+			status:        "alarm",
+			severity:      "HIGH",
+			expectedKind:  "fail",
+			expectedLevel: "error",
+		},
+		"alarm medium": {
+			status:        "alarm",
+			severity:      "medium",
+			expectedKind:  "fail",
+			expectedLevel: "warning",
+		},
+		"alarm low": {
+			status:        "alarm",
+			severity:      "low",
+			expectedKind:  "fail",
+			expectedLevel: "note",
+		},
+		"alarm no severity": {
+			status:        "alarm",
+			expectedKind:  "fail",
+			expectedLevel: "warning",
+		},
+		"error": {
+			status:        "error",
+			severity:      "low",
+			expectedKind:  "fail",
+			expectedLevel: "error",
+		},
+		"ok": {
+			status:        "ok",
+			severity:      "critical",
+			expectedKind:  "pass",
+			expectedLevel: "none",
+		},
+		"info": {
+			status:        "info",
+			expectedKind:  "informational",
+			expectedLevel: "none",
+		},
+		"skip": {
+			status:        "skip",
+			expectedKind:  "notApplicable",
+			expectedLevel: "none",
+		},
+		"suppressed": {
+			status:        "suppressed",
+			severity:      "high",
+			expectedKind:  "fail",
+			expectedLevel: "error",
+		},
+	}
+}
+
+func TestSarifLevel(t *testing.T) {
+	for name, test := range testCasesSarifLevel() {
+		row := &controlexecute.ResultRow{
+			Status: test.status,
+			Run:    &controlexecute.ControlRun{Severity: test.severity},
+		}
+		if kind := sarifKindForStatus(row.Status); kind != test.expectedKind {
+			t.Errorf("Test: '%s'' FAILED : expected kind '%s', got '%s'", name, test.expectedKind, kind)
+		}
+		if level := sarifLevelForRow(row); level != test.expectedLevel {
+			t.Errorf("Test: '%s'' FAILED : expected level '%s', got '%s'", name, test.expectedLevel, level)
+		}
+	}
+}
+
+func TestSarifResultProperties(t *testing.T) {
+	row := &controlexecute.ResultRow{
+		Status: "alarm",
+		Run:    &controlexecute.ControlRun{},
+		Dimensions: []controlexecute.Dimension{
+			{Key: "status", Value: "running"},
+			{Key: "region", Value: "us-east-1"},
+		},
+	}
+	properties := newSarifResult(row, 0, nil).Properties
+	if status := properties["status"]; status != "alarm" {
+		t.Errorf("expected status property 'alarm', got '%v'", status)
+	}
+	dimensions, ok := properties["dimensions"].(map[string]string)
+	if !ok {
+		t.Fatalf("expected dimensions property, got '%v'", properties["dimensions"])
+	}
+	if dimensions["status"] != "running" || dimensions["region"] != "us-east-1" {
+		t.Errorf("unexpected dimensions property: %v", dimensions)
+	}
+}
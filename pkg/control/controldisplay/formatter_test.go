@@ -76,6 +76,22 @@ var formatterTestCase = []testCase{
 			name:      "nunit3",
 		},
 	},
+	{
+		input: "junit.xml",
+		expected: testFormatter{
+			alias:     "junit.xml",
+			extension: ".junit.xml",
+			name:      "junit",
+		},
+	},
+	{
+		input: "sarif",
+		expected: testFormatter{
+			alias:     "",
+			extension: ".sarif",
+			name:      "sarif",
+		},
+	},
 }
 
 func TestFormatResolver(t *testing.T) {
@@ -3,6 +3,8 @@ package controldisplay
 import (
 	"fmt"
 	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
 )
 
 type ResultStatusRenderer struct {
@@ -32,6 +34,11 @@ func (r ResultStatusRenderer) Render() string {
 }
 
 // pad out status toi length of longest status string = "ERROR" - 5 chars
+// (suppressed is abbreviated to keep the results aligned)
 func (r ResultStatusRenderer) paddedStatusString() string {
-	return fmt.Sprintf("%-5s", strings.ToUpper(r.status))
+	status := r.status
+	if status == constants.ControlSuppressed {
+		status = "suppr"
+	}
+	return fmt.Sprintf("%-5s", strings.ToUpper(status))
 }
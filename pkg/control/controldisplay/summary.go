@@ -61,6 +61,10 @@ func (r SummaryRenderer) Render() string {
 		alarmStatusRow,
 		errorStatusRow,
 	}
+	// only show suppressed results if there are any
+	if r.resultTree.Root.Summary.Status.Suppressed > 0 {
+		summaryLines = append(summaryLines, NewSummaryStatusRowRenderer(r.resultTree, availableWidth, "suppressed").Render())
+	}
 	// if there is a severity block, add it
 	if len(severityRows) > 0 {
 		summaryLines = append(summaryLines, "") // blank line
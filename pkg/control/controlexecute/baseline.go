@@ -0,0 +1,142 @@
+package controlexecute
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// BaselineState is the state of a result row compared to a baseline
+type BaselineState string
+
+const (
+	// BaselineStateNew - the row was not present in the baseline
+	BaselineStateNew BaselineState = "new"
+	// BaselineStateUnchanged - the row was present in the baseline with the same status
+	BaselineStateUnchanged BaselineState = "unchanged"
+	// BaselineStateResolved - the row was present in the baseline but is no longer returned
+	BaselineStateResolved BaselineState = "resolved"
+)
+
+// Baseline is a set of control results loaded from a previous JSON export of an execution tree
+// results are keyed by control + resource + dimensions
+type Baseline struct {
+	Path string
+	rows map[string]*BaselineRow
+}
+
+// BaselineRow is a single control result row from a baseline
+type BaselineRow struct {
+	ControlId  string      `json:"control_id"`
+	Reason     string      `json:"reason"`
+	Resource   string      `json:"resource"`
+	Status     string      `json:"status"`
+	Dimensions []Dimension `json:"dimensions"`
+}
+
+// BaselineSummary is the result of comparing an execution tree with a baseline
+type BaselineSummary struct {
+	New       int
+	Unchanged int
+	// the baseline rows which are no longer returned
+	Resolved []*BaselineRow
+	// counts of new alarm and error rows - used to determine the exit code
+	NewAlarms int
+	NewErrors int
+}
+
+// the structure of the json export format - only the properties required for the baseline are parsed
+type baselineGroup struct {
+	Groups   []*baselineGroup   `json:"groups"`
+	Controls []*baselineControl `json:"controls"`
+}
+
+type baselineControl struct {
+	ControlId string         `json:"control_id"`
+	Results   []*BaselineRow `json:"results"`
+}
+
+// LoadBaseline loads a baseline from a JSON export file
+func LoadBaseline(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file '%s': %s", path, err.Error())
+	}
+	var root baselineGroup
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file '%s' - baseline must be a check JSON export: %s", path, err.Error())
+	}
+	res := &Baseline{
+		Path: path,
+		rows: make(map[string]*BaselineRow),
+	}
+	res.addGroup(&root)
+	return res, nil
+}
+
+func (b *Baseline) addGroup(group *baselineGroup) {
+	for _, control := range group.Controls {
+		for _, row := range control.Results {
+			row.ControlId = control.ControlId
+			b.rows[ResultRowKey(row.ControlId, row.Resource, row.Dimensions)] = row
+		}
+	}
+	for _, child := range group.Groups {
+		b.addGroup(child)
+	}
+}
+
+// Apply compares the result rows of the (executed) execution tree with the baseline,
+// setting the BaselineState of each row and populating the tree BaselineSummary
+func (b *Baseline) Apply(tree *ExecutionTree) *BaselineSummary {
+	summary := &BaselineSummary{}
+	// keep track of the matched baseline rows and the controls which were run
+	matched := make(map[string]bool)
+	runControls := make(map[string]bool)
+
+	for _, run := range tree.ControlRuns {
+		runControls[run.ControlId] = true
+		// a control run error cannot be baselined - always count it as a new error
+		if run.runError != nil {
+			summary.NewErrors++
+		}
+		for _, row := range run.Rows {
+			key := ResultRowKey(run.ControlId, row.Resource, row.Dimensions)
+			if baselineRow, ok := b.rows[key]; ok && baselineRow.Status == row.Status {
+				row.BaselineState = BaselineStateUnchanged
+				matched[key] = true
+				summary.Unchanged++
+				continue
+			}
+			row.BaselineState = BaselineStateNew
+			summary.New++
+			switch row.Status {
+			case constants.ControlAlarm:
+				summary.NewAlarms++
+			case constants.ControlError:
+				summary.NewErrors++
+			}
+		}
+	}
+
+	// any baseline rows for controls which were run, which are not in the current results, have been resolved
+	for key, row := range b.rows {
+		if matched[key] || !runControls[row.ControlId] {
+			continue
+		}
+		// only rows with a failing status are considered resolved
+		if row.Status == constants.ControlAlarm || row.Status == constants.ControlError {
+			summary.Resolved = append(summary.Resolved, row)
+		}
+	}
+	sort.Slice(summary.Resolved, func(i, j int) bool {
+		return ResultRowKey(summary.Resolved[i].ControlId, summary.Resolved[i].Resource, summary.Resolved[i].Dimensions) <
+			ResultRowKey(summary.Resolved[j].ControlId, summary.Resolved[j].Resource, summary.Resolved[j].Dimensions)
+	})
+
+	tree.Baseline = summary
+	return summary
+}
@@ -0,0 +1,76 @@
+package controlexecute
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testBaselineJson = `{
+	"group_id": "root_result_group",
+	"groups": [
+		{
+			"group_id": "benchmark.b1",
+			"groups": [],
+			"controls": [
+				{
+					"control_id": "control.c1",
+					"results": [
+						{"reason": "r", "resource": "res1", "status": "alarm", "dimensions": [{"key": "region", "value": "us-east-1"}]},
+						{"reason": "r", "resource": "res2", "status": "alarm", "dimensions": []},
+						{"reason": "r", "resource": "res3", "status": "ok", "dimensions": []}
+					]
+				},
+				{
+					"control_id": "control.not_run",
+					"results": [
+						{"reason": "r", "resource": "res1", "status": "alarm", "dimensions": []}
+					]
+				}
+			]
+		}
+	],
+	"controls": null
+}`
+
+func TestBaselineApply(t *testing.T) {
+	baselinePath := filepath.Join(t.TempDir(), "baseline.json")
+	if err := os.WriteFile(baselinePath, []byte(testBaselineJson), 0644); err != nil {
+		t.Fatal(err)
+	}
+	baseline, err := LoadBaseline(baselinePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unchangedRow := &ResultRow{Resource: "res1", Status: "alarm", Dimensions: []Dimension{{Key: "region", Value: "us-east-1"}}}
+	newRow := &ResultRow{Resource: "res1", Status: "alarm", Dimensions: []Dimension{{Key: "region", Value: "us-west-2"}}}
+	changedRow := &ResultRow{Resource: "res3", Status: "error"}
+	tree := &ExecutionTree{
+		ControlRuns: []*ControlRun{
+			{ControlId: "control.c1", Rows: ResultRows{unchangedRow, newRow, changedRow}},
+		},
+	}
+
+	summary := baseline.Apply(tree)
+
+	if unchangedRow.BaselineState != BaselineStateUnchanged {
+		t.Errorf("expected row to be %s, got %s", BaselineStateUnchanged, unchangedRow.BaselineState)
+	}
+	if newRow.BaselineState != BaselineStateNew {
+		t.Errorf("expected row to be %s, got %s", BaselineStateNew, newRow.BaselineState)
+	}
+	if changedRow.BaselineState != BaselineStateNew {
+		t.Errorf("expected row with changed status to be %s, got %s", BaselineStateNew, changedRow.BaselineState)
+	}
+	if summary.New != 2 || summary.Unchanged != 1 || summary.NewAlarms != 1 || summary.NewErrors != 1 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+	// res2 is resolved - controls which were not run must be ignored
+	if len(summary.Resolved) != 1 || summary.Resolved[0].Resource != "res2" {
+		t.Errorf("expected res2 to be resolved, got %v", summary.Resolved)
+	}
+	if tree.Baseline != summary {
+		t.Errorf("expected baseline summary to be set on the tree")
+	}
+}
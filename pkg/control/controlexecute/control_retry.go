@@ -0,0 +1,54 @@
+package controlexecute
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+)
+
+// controlRetryPolicy determines the timeout for each control execution attempt,
+// and whether (and after what delay) a control which errors should be retried
+type controlRetryPolicy struct {
+	timeout time.Duration
+	retries int
+	// the delay before the first retry - this is doubled for each subsequent retry
+	initialBackoff time.Duration
+}
+
+func newControlRetryPolicy() *controlRetryPolicy {
+	backoffSecs := constants.DefaultControlRetryBackoffSecs
+	if viper.IsSet(constants.ArgControlRetryBackoff) {
+		backoffSecs = viper.GetInt(constants.ArgControlRetryBackoff)
+	}
+	return &controlRetryPolicy{
+		timeout:        time.Duration(viper.GetInt(constants.ArgControlTimeout)) * time.Second,
+		retries:        viper.GetInt(constants.ArgControlRetries),
+		initialBackoff: time.Duration(backoffSecs) * time.Second,
+	}
+}
+
+// shouldRetry returns whether a control which failed with the given error after the given number of attempts should be retried
+func (p *controlRetryPolicy) shouldRetry(ctx context.Context, err error, attempts int) bool {
+	// never retry if the execution has been cancelled
+	if ctx.Err() != nil || error_helpers.IsCancelledError(err) {
+		return false
+	}
+	maxAttempts := p.retries + 1
+	// always retry plugin connectivity errors (i.e. the plugin crashed) at least once
+	if grpc.IsGRPCConnectivityError(err) && maxAttempts < constants.MaxControlRunAttempts {
+		maxAttempts = constants.MaxControlRunAttempts
+	}
+	return attempts < maxAttempts
+}
+
+// backoff returns the delay before the next attempt, given the number of attempts made so far
+func (p *controlRetryPolicy) backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return p.initialBackoff * time.Duration(1<<(attempts-1))
+}
@@ -0,0 +1,47 @@
+package controlexecute
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestControlRetryPolicyShouldRetry(t *testing.T) {
+	policy := &controlRetryPolicy{retries: 2, initialBackoff: time.Second}
+	err := errors.New("rate limit exceeded")
+
+	if !policy.shouldRetry(context.Background(), err, 1) {
+		t.Errorf("expected a retry after 1 attempt")
+	}
+	if !policy.shouldRetry(context.Background(), err, 2) {
+		t.Errorf("expected a retry after 2 attempts")
+	}
+	if policy.shouldRetry(context.Background(), err, 3) {
+		t.Errorf("expected no retry after 3 attempts")
+	}
+	if policy.shouldRetry(context.Background(), context.Canceled, 1) {
+		t.Errorf("expected no retry for a cancelled error")
+	}
+
+	cancelledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if policy.shouldRetry(cancelledCtx, err, 1) {
+		t.Errorf("expected no retry when the context is cancelled")
+	}
+
+	noRetries := &controlRetryPolicy{}
+	if noRetries.shouldRetry(context.Background(), err, 1) {
+		t.Errorf("expected no retry when retries are not configured")
+	}
+}
+
+func TestControlRetryPolicyBackoff(t *testing.T) {
+	policy := &controlRetryPolicy{initialBackoff: 2 * time.Second}
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second}
+	for i, want := range expected {
+		if got := policy.backoff(i + 1); got != want {
+			t.Errorf("attempt %d: expected backoff %s, got %s", i+1, want, got)
+		}
+	}
+}
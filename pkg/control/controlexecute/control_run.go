@@ -2,13 +2,13 @@ package controlexecute
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"sync"
 	"time"
 
 	typehelpers "github.com/turbot/go-kit/types"
-	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/control/controlstatus"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
@@ -54,6 +54,8 @@ type ControlRun struct {
 
 	// execution duration
 	Duration time.Duration `json:"-"`
+	// the number of times the control query was executed - greater than 1 if the control was retried
+	Attempts int `json:"attempts,omitempty"`
 	// parent result group
 	Group *ResultGroup `json:"-"`
 	// execution tree
@@ -66,7 +68,8 @@ type ControlRun struct {
 	rowMap      map[string]ResultRows
 	stateLock   sync.Mutex
 	doneChan    chan bool
-	attempts    int
+	// the exemptions which apply to this control
+	exemptions []*modconfig.Exemption
 }
 
 func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree *ExecutionTree) *ControlRun {
@@ -97,6 +100,8 @@ func NewControlRun(control *modconfig.Control, group *ResultGroup, executionTree
 		Group:    group,
 		NodeType: modconfig.BlockTypeControl,
 		doneChan: make(chan bool, 1),
+		// find the exemptions which apply to this control
+		exemptions: executionTree.getExemptions(control, group),
 	}
 	return res
 }
@@ -161,7 +166,7 @@ func (r *ControlRun) setError(ctx context.Context, err error) {
 	if err == nil {
 		return
 	}
-	if r.runError == context.DeadlineExceeded {
+	if errors.Is(err, context.DeadlineExceeded) {
 		r.runError = fmt.Errorf("control execution timed out")
 	} else {
 		r.runError = error_helpers.TransformErrorToSteampipe(err)
@@ -205,23 +210,6 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 		log.Printf("[TRACE] finishing with concurrency, %s, , %d\n", r.Control.Name(), r.Tree.Progress.Executing)
 	}()
 
-	// get a db connection
-	sessionResult := r.acquireSession(ctx, client)
-	if sessionResult.Error != nil {
-		if !error_helpers.IsCancelledError(sessionResult.Error) {
-			log.Printf("[TRACE] controlRun %s execute failed to acquire session: %s", r.ControlId, sessionResult.Error)
-			sessionResult.Error = fmt.Errorf("error acquiring database connection, %s", sessionResult.Error.Error())
-			r.setError(ctx, sessionResult.Error)
-		}
-		return
-	}
-
-	dbSession := sessionResult.Session
-	defer func() {
-		// do this in a closure, otherwise the argument will not get evaluated during calltime
-		dbSession.Close(error_helpers.IsContextCanceled(ctx))
-	}()
-
 	// set our status
 	r.RunStatus = dashboardtypes.RunRunning
 
@@ -243,38 +231,90 @@ func (r *ControlRun) execute(ctx context.Context, client db_common.Client) {
 		return
 	}
 
+	defer func() {
+		dimensionsSchema := r.getDimensionSchema()
+		// convert the data to snapshot format
+		r.Data = r.Rows.ToLeafData(dimensionsSchema)
+	}()
+
+	policy := newControlRetryPolicy()
+	for {
+		r.Attempts++
+		err := r.executeAttempt(ctx, client, resolvedQuery, policy.timeout)
+		if err == nil {
+			return
+		}
+		if !policy.shouldRetry(ctx, err, r.Attempts) {
+			if r.Attempts > 1 {
+				err = fmt.Errorf("%w (after %d attempts)", err, r.Attempts)
+			}
+			r.setError(ctx, err)
+			return
+		}
+
+		backoff := policy.backoff(r.Attempts)
+		log.Printf("[TRACE] control %s attempt %d failed with error %s - retrying in %s…", control.Name(), r.Attempts, err, backoff)
+		select {
+		case <-ctx.Done():
+			r.setError(ctx, ctx.Err())
+			return
+		case <-time.After(backoff):
+		}
+		// clear any results from the failed attempt
+		r.resetResults()
+	}
+}
+
+// executeAttempt makes a single attempt to execute the control query and wait for the results
+// if a timeout is specified, the attempt is cancelled if it has not completed within the timeout
+func (r *ControlRun) executeAttempt(ctx context.Context, client db_common.Client, resolvedQuery *modconfig.ResolvedQuery, timeout time.Duration) (err error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+		defer func() {
+			// if the attempt failed because the timeout was exceeded, return a more helpful error
+			if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				err = fmt.Errorf("control execution timed out after %s", timeout)
+			}
+		}()
+	}
+
+	// get a db connection
+	sessionResult := r.acquireSession(ctx, client)
+	if sessionResult.Error != nil {
+		if error_helpers.IsCancelledError(sessionResult.Error) {
+			return sessionResult.Error
+		}
+		log.Printf("[TRACE] controlRun %s execute failed to acquire session: %s", r.ControlId, sessionResult.Error)
+		return fmt.Errorf("error acquiring database connection, %s", sessionResult.Error.Error())
+	}
+
+	dbSession := sessionResult.Session
+	defer func() {
+		// do this in a closure, otherwise the argument will not get evaluated during calltime
+		// (if this attempt timed out, wait for the connection to be cleaned up before releasing it)
+		dbSession.Close(ctx.Err() != nil)
+	}()
+
 	controlExecutionCtx := r.getControlQueryContext(ctx)
 
 	// execute the control query
 	// NOTE no need to pass an OnComplete callback - we are already closing our session after waiting for results
-	log.Printf("[TRACE] execute start for, %s\n", control.Name())
+	log.Printf("[TRACE] execute start for, %s\n", r.Control.Name())
 	queryResult, err := client.ExecuteInSession(controlExecutionCtx, dbSession, nil, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
-	log.Printf("[TRACE] execute finish for, %s\n", control.Name())
-
+	log.Printf("[TRACE] execute finish for, %s\n", r.Control.Name())
 	if err != nil {
-		r.attempts++
-
-		// is this an rpc EOF error - meaning that the plugin somehow crashed
-		if grpc.IsGRPCConnectivityError(err) {
-			if r.attempts < constants.MaxControlRunAttempts {
-				log.Printf("[TRACE] control %s query failed with plugin connectivity error %s - retrying…", r.Control.Name(), err)
-				// recurse into this function to retry using the original context - which Execute will use to create it's own timeout context
-				r.execute(ctx, client)
-				return
-			} else {
-				log.Printf("[TRACE] control %s query failed again with plugin connectivity error %s - NOT retrying…", r.Control.Name(), err)
-			}
-		}
-		r.setError(ctx, err)
-		return
+		return err
 	}
 
 	r.queryResult = queryResult
 
 	// now wait for control completion
-	log.Printf("[TRACE] wait result for, %s\n", control.Name())
-	r.waitForResults(ctx)
-	log.Printf("[TRACE] finish result for, %s\n", control.Name())
+	log.Printf("[TRACE] wait result for, %s\n", r.Control.Name())
+	err = r.waitForResults(ctx)
+	log.Printf("[TRACE] finish result for, %s\n", r.Control.Name())
+	return err
 }
 
 // try to acquire a database session - retry up to 4 times if there is an error
@@ -308,46 +348,46 @@ func (r *ControlRun) resolveControlQuery(control *modconfig.Control) (*modconfig
 	return resolvedQuery, nil
 }
 
-func (r *ControlRun) waitForResults(ctx context.Context) {
-	defer func() {
-		dimensionsSchema := r.getDimensionSchema()
-		// convert the data to snapshot format
-		r.Data = r.Rows.ToLeafData(dimensionsSchema)
-	}()
-
+// waitForResults reads the query results, returning any error
+func (r *ControlRun) waitForResults(ctx context.Context) error {
 	for {
 		select {
 		case <-ctx.Done():
-			r.setError(ctx, ctx.Err())
-			return
+			return ctx.Err()
 		case row := <-*r.queryResult.RowChan:
 			// nil row means control run is complete
 			if row == nil {
 				// nil row means we are done
 				r.setRunStatus(ctx, dashboardtypes.RunComplete)
 				r.createdOrderedResultRows()
-				return
+				return nil
 			}
 			// if the row is in error then we terminate the run
 			if row.Error != nil {
-				// set error status (parent summary will be set from parent defer)
-				r.setError(ctx, row.Error)
-				return
+				return row.Error
 			}
 
 			// so all is ok - create another result row
 			result, err := NewResultRow(r, row, r.queryResult.Cols)
 			if err != nil {
-				r.setError(ctx, err)
-				return
+				return err
 			}
+			r.applyExemptions(result)
 			r.addResultRow(result)
 		case <-r.doneChan:
-			return
+			return nil
 		}
 	}
 }
 
+// resetResults clears the results and summary counts from a failed execution attempt
+func (r *ControlRun) resetResults() {
+	r.rowMap = make(map[string]ResultRows)
+	r.Rows = nil
+	r.Summary = &controlstatus.StatusSummary{}
+	r.queryResult = nil
+}
+
 func (r *ControlRun) getDimensionSchema() map[string]*queryresult.ColumnDef {
 	var dimensionsSchema = make(map[string]*queryresult.ColumnDef)
 
@@ -386,12 +426,14 @@ func (r *ControlRun) addResultRow(row *ResultRow) {
 		r.Summary.Info++
 	case constants.ControlError:
 		r.Summary.Error++
+	case constants.ControlSuppressed:
+		r.Summary.Suppressed++
 	}
 }
 
 // populate ordered list of rows
 func (r *ControlRun) createdOrderedResultRows() {
-	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSkip}
+	statusOrder := []string{constants.ControlError, constants.ControlAlarm, constants.ControlInfo, constants.ControlOk, constants.ControlSkip, constants.ControlSuppressed}
 	for _, status := range statusOrder {
 		r.Rows = append(r.Rows, r.rowMap[status]...)
 	}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
+	typehelpers "github.com/turbot/go-kit/types"
 	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
 	"github.com/turbot/steampipe/pkg/connection_sync"
 	"github.com/turbot/steampipe/pkg/constants"
@@ -35,9 +36,13 @@ type ExecutionTree struct {
 	// the current session search path
 	SearchPath []string             `json:"-"`
 	Workspace  *workspace.Workspace `json:"-"`
-	client     db_common.Client
+	// the result of comparing the results with a baseline - only set if a baseline was provided
+	Baseline *BaselineSummary `json:"-"`
+	client   db_common.Client
 	// an optional map of control names used to filter the controls which are run
 	controlNameFilterMap map[string]bool
+	// an optional map of severities used to filter the controls which are run
+	severityFilterMap map[string]bool
 }
 
 func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, client db_common.Client, controlFilterWhereClause string, args ...string) (*ExecutionTree, error) {
@@ -60,6 +65,8 @@ func NewExecutionTree(ctx context.Context, workspace *workspace.Workspace, clien
 	if err != nil {
 		return nil, err
 	}
+	// if a "--severity" parameter was passed, build a map of severities used to filter the controls to run
+	executionTree.populateSeverityFilterMap(viper.GetStringSlice(constants.ArgSeverity))
 
 	var resolvedItem modconfig.ModTreeItem
 
@@ -101,7 +108,7 @@ func (*ExecutionTree) IsExportSourceData() {}
 // if so, creates a ControlRun, which is added to the parent group
 func (e *ExecutionTree) AddControl(ctx context.Context, control *modconfig.Control, group *ResultGroup) {
 	// note we use short name to determine whether to include a control
-	if e.ShouldIncludeControl(control.ShortName) {
+	if e.ShouldIncludeControl(control.ShortName) && e.ShouldIncludeSeverity(typehelpers.SafeString(control.Severity)) {
 		// create new ControlRun with treeItem as the parent
 		controlRun := NewControlRun(control, group, e)
 		// add it into the group
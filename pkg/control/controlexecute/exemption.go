@@ -0,0 +1,78 @@
+package controlexecute
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// getExemptions returns the unexpired exemptions which target the control,
+// either directly or via one of the benchmarks in the group hierarchy
+func (e *ExecutionTree) getExemptions(control *modconfig.Control, group *ResultGroup) []*modconfig.Exemption {
+	var res []*modconfig.Exemption
+	now := time.Now()
+	for _, exemption := range e.Workspace.GetResourceMaps().Exemptions {
+		if !exemptionInScope(exemption, e.Workspace.Mod, control) || !exemptionAppliesTo(exemption, control, group) {
+			continue
+		}
+		if exemption.IsExpired(now) {
+			log.Printf("[INFO] %s has expired and will not be applied to %s", exemption.Name(), control.Name())
+			continue
+		}
+		res = append(res, exemption)
+	}
+	return res
+}
+
+// exemptionInScope returns whether the exemption may be applied to the control - exemptions declared in the
+// workspace mod apply to any control, whereas exemptions declared in a dependency mod only apply to the controls of that mod
+func exemptionInScope(exemption *modconfig.Exemption, workspaceMod *modconfig.Mod, control *modconfig.Control) bool {
+	exemptionMod := exemption.GetMod()
+	if exemptionMod == nil {
+		return false
+	}
+	if workspaceMod != nil && exemptionMod.Name() == workspaceMod.Name() {
+		return true
+	}
+	return control.GetMod() != nil && exemptionMod.Name() == control.GetMod().Name()
+}
+
+func exemptionAppliesTo(exemption *modconfig.Exemption, control *modconfig.Control, group *ResultGroup) bool {
+	if exemption.AppliesTo(control.Name()) {
+		return true
+	}
+	for g := group; g != nil; g = g.Parent {
+		if g.GroupItem != nil && exemption.AppliesTo(g.GroupItem.Name()) {
+			return true
+		}
+	}
+	return false
+}
+
+// applyExemptions sets the status of an alarm or error row to 'suppressed' if it matches one of the control run exemptions
+// (rows with other statuses are left unchanged, so it is apparent when an exemption is no longer needed)
+func (r *ControlRun) applyExemptions(row *ResultRow) {
+	if row.Status != constants.ControlAlarm && row.Status != constants.ControlError {
+		return
+	}
+	getDimension := func(key string) (string, bool) {
+		for _, d := range row.Dimensions {
+			if d.Key == key {
+				return d.Value, true
+			}
+		}
+		return "", false
+	}
+	for _, exemption := range r.exemptions {
+		if exemption.Matches(row.Resource, getDimension) {
+			row.Status = constants.ControlSuppressed
+			row.Exemption = exemption.Name()
+			row.Reason = fmt.Sprintf("%s [suppressed by %s: %s]", row.Reason, exemption.GetUnqualifiedName(), typehelpers.SafeString(exemption.Reason))
+			return
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+type exemptionInScopeTest struct {
+	exemptionMod string
+	controlMod   string
+	expected     bool
+}
+
+var exemptionInScopeTestCases = map[string]exemptionInScopeTest{
+	"workspace exemption, workspace control":  {exemptionMod: "local", controlMod: "local", expected: true},
+	"workspace exemption, dependency control": {exemptionMod: "local", controlMod: "dep", expected: true},
+	"dependency exemption, same mod control":  {exemptionMod: "dep", controlMod: "dep", expected: true},
+	"dependency exemption, workspace control": {exemptionMod: "dep", controlMod: "local", expected: false},
+	"dependency exemption, other mod control": {exemptionMod: "dep", controlMod: "other", expected: false},
+}
+
+func TestExemptionInScope(t *testing.T) {
+	workspaceMod := modconfig.NewMod("local", "", hcl.Range{})
+	for name, test := range exemptionInScopeTestCases {
+		exemption := &modconfig.Exemption{}
+		exemption.Mod = modconfig.NewMod(test.exemptionMod, "", hcl.Range{})
+		control := &modconfig.Control{}
+		control.Mod = modconfig.NewMod(test.controlMod, "", hcl.Range{})
+		if actual := exemptionInScope(exemption, workspaceMod, control); actual != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
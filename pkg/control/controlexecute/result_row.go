@@ -2,6 +2,8 @@ package controlexecute
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/turbot/go-kit/helpers"
 	typehelpers "github.com/turbot/go-kit/types"
@@ -47,10 +49,14 @@ type ResultRow struct {
 	Reason string `json:"reason" csv:"reason"`
 	// resource name
 	Resource string `json:"resource" csv:"resource"`
-	// status of the row (ok, info, alarm, error, skip)
+	// status of the row (ok, info, alarm, error, skip, suppressed)
 	Status string `json:"status" csv:"status"`
+	// the name of the exemption which suppressed this row - only set if the status is suppressed
+	Exemption string `json:"exemption,omitempty"`
 	// dimensions for this row
 	Dimensions []Dimension `json:"dimensions"`
+	// state of the row compared to the baseline (new, unchanged) - only set if a baseline was provided
+	BaselineState BaselineState `json:"baseline_state,omitempty"`
 	// parent control run
 	Run *ControlRun `json:"-"`
 	// source control
@@ -142,3 +148,13 @@ func columnTypesContainsColumn(col string, colTypes []*queryresult.ColumnDef) bo
 	}
 	return false
 }
+
+// ResultRowKey builds the key used to match result rows between runs - control id, resource and sorted dimensions
+func ResultRowKey(controlId, resource string, dimensions []Dimension) string {
+	dimensionStrings := make([]string, len(dimensions))
+	for i, d := range dimensions {
+		dimensionStrings[i] = fmt.Sprintf("%s=%s", d.Key, d.Value)
+	}
+	sort.Strings(dimensionStrings)
+	return fmt.Sprintf("%s|%s|%s", controlId, resource, strings.Join(dimensionStrings, ","))
+}
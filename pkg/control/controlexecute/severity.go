@@ -0,0 +1,78 @@
+package controlexecute
+
+import (
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// severityRank returns the position of the severity in constants.ControlSeverities, or -1 if it is not recognised
+// an empty severity is treated as 'none'
+func severityRank(severity string) int {
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if severity == "" {
+		severity = constants.ControlSeverityNone
+	}
+	for i, s := range constants.ControlSeverities {
+		if s == severity {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsValidSeverity returns whether the severity is one of constants.ControlSeverities
+func IsValidSeverity(severity string) bool {
+	return severityRank(severity) != -1
+}
+
+// SeverityAtOrAbove returns whether the severity is at or above the threshold severity
+// unrecognised severities are never at or above the threshold
+func SeverityAtOrAbove(severity, threshold string) bool {
+	rank := severityRank(severity)
+	return rank != -1 && rank >= severityRank(threshold)
+}
+
+// populateSeverityFilterMap builds a map of the severities of the controls to run
+func (e *ExecutionTree) populateSeverityFilterMap(severities []string) {
+	if len(severities) == 0 {
+		return
+	}
+	e.severityFilterMap = make(map[string]bool, len(severities))
+	for _, s := range severities {
+		e.severityFilterMap[strings.ToLower(strings.TrimSpace(s))] = true
+	}
+}
+
+// ShouldIncludeSeverity returns whether a control with the given severity should be included in the tree
+func (e *ExecutionTree) ShouldIncludeSeverity(severity string) bool {
+	if e.severityFilterMap == nil {
+		return true
+	}
+	severity = strings.ToLower(strings.TrimSpace(severity))
+	if severity == "" {
+		severity = constants.ControlSeverityNone
+	}
+	return e.severityFilterMap[severity]
+}
+
+// AlarmCountAtSeverity returns the number of alarms raised by controls with a severity at or above the threshold
+// if a baseline was provided, only new alarms are counted
+func (e *ExecutionTree) AlarmCountAtSeverity(threshold string) int {
+	count := 0
+	for _, run := range e.ControlRuns {
+		if !SeverityAtOrAbove(run.Severity, threshold) {
+			continue
+		}
+		for _, row := range run.Rows {
+			if row.Status != constants.ControlAlarm {
+				continue
+			}
+			if e.Baseline != nil && row.BaselineState != BaselineStateNew {
+				continue
+			}
+			count++
+		}
+	}
+	return count
+}
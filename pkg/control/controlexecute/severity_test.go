@@ -0,0 +1,62 @@
+package controlexecute
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+type severityAtOrAboveTest struct {
+	severity  string
+	threshold string
+	expected  bool
+}
+
+var severityAtOrAboveTestCases = map[string]severityAtOrAboveTest{
+	"equal":                 {severity: "high", threshold: "high", expected: true},
+	"above":                 {severity: "critical", threshold: "high", expected: true},
+	"below":                 {severity: "medium", threshold: "high", expected: false},
+	"case insensitive":      {severity: "HIGH", threshold: "high", expected: true},
+	"empty is none":         {severity: "", threshold: "low", expected: false},
+	"empty at none":         {severity: "", threshold: "none", expected: true},
+	"unrecognised severity": {severity: "urgent", threshold: "none", expected: false},
+}
+
+func TestSeverityAtOrAbove(t *testing.T) {
+	for name, test := range severityAtOrAboveTestCases {
+		if actual := SeverityAtOrAbove(test.severity, test.threshold); actual != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
+
+func TestAlarmCountAtSeverity(t *testing.T) {
+	newRun := func(severity string, statuses ...string) *ControlRun {
+		run := &ControlRun{Severity: severity}
+		for _, status := range statuses {
+			run.Rows = append(run.Rows, &ResultRow{Status: status, BaselineState: BaselineStateNew})
+		}
+		return run
+	}
+	tree := &ExecutionTree{
+		ControlRuns: []*ControlRun{
+			newRun("low", constants.ControlAlarm, constants.ControlAlarm),
+			newRun("high", constants.ControlAlarm, constants.ControlOk),
+			newRun("critical", constants.ControlAlarm, constants.ControlError),
+			newRun("", constants.ControlAlarm),
+		},
+	}
+	if count := tree.AlarmCountAtSeverity("high"); count != 2 {
+		t.Errorf("expected 2 alarms at or above high, got %d", count)
+	}
+	if count := tree.AlarmCountAtSeverity("none"); count != 5 {
+		t.Errorf("expected 5 alarms at or above none, got %d", count)
+	}
+
+	// with a baseline, only new alarms are counted
+	tree.Baseline = &BaselineSummary{}
+	tree.ControlRuns[2].Rows[0].BaselineState = BaselineStateUnchanged
+	if count := tree.AlarmCountAtSeverity("high"); count != 1 {
+		t.Errorf("expected 1 new alarm at or above high, got %d", count)
+	}
+}
@@ -2,11 +2,12 @@ package controlstatus
 
 // StatusSummary is a struct containing the counts of each possible control status
 type StatusSummary struct {
-	Alarm int `json:"alarm"`
-	Ok    int `json:"ok"`
-	Info  int `json:"info"`
-	Skip  int `json:"skip"`
-	Error int `json:"error"`
+	Alarm      int `json:"alarm"`
+	Ok         int `json:"ok"`
+	Info       int `json:"info"`
+	Skip       int `json:"skip"`
+	Error      int `json:"error"`
+	Suppressed int `json:"suppressed"`
 }
 
 func (s *StatusSummary) PassedCount() int {
@@ -17,8 +18,13 @@ func (s *StatusSummary) FailedCount() int {
 	return s.Alarm + s.Error
 }
 
+// SkippedCount returns the count of results which are neither passed nor failed
+func (s *StatusSummary) SkippedCount() int {
+	return s.Skip + s.Suppressed
+}
+
 func (s *StatusSummary) TotalCount() int {
-	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error
+	return s.Alarm + s.Ok + s.Info + s.Skip + s.Error + s.Suppressed
 }
 
 func (s *StatusSummary) Merge(summary *StatusSummary) {
@@ -27,4 +33,5 @@ func (s *StatusSummary) Merge(summary *StatusSummary) {
 	s.Info += summary.Info
 	s.Skip += summary.Skip
 	s.Error += summary.Error
+	s.Suppressed += summary.Suppressed
 }
@@ -2,15 +2,13 @@ package dashboardexecute
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 	"sync"
 	"time"
 
-	filehelpers "github.com/turbot/go-kit/files"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardevents"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardsnapshot"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
 	"github.com/turbot/steampipe/pkg/db/db_common"
 	"github.com/turbot/steampipe/pkg/utils"
@@ -37,7 +35,18 @@ func newDashboardExecutor() *DashboardExecutor {
 
 var Executor = newDashboardExecutor()
 
-func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client) (err error) {
+func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client) error {
+	// inputs must be provided before execution unless this is an interactive execution
+	return e.executeDashboard(ctx, sessionId, dashboardName, inputs, workspace, client, !e.interactive)
+}
+
+// ExecuteDashboardWithInputs executes the dashboard for a session which cannot provide inputs once execution
+// has started (e.g. a dashboard server API request), so all inputs must be provided up front
+func (e *DashboardExecutor) ExecuteDashboardWithInputs(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client) error {
+	return e.executeDashboard(ctx, sessionId, dashboardName, inputs, workspace, client, true)
+}
+
+func (e *DashboardExecutor) executeDashboard(ctx context.Context, sessionId, dashboardName string, inputs map[string]any, workspace *workspace.Workspace, client db_common.Client, requireInputs bool) (err error) {
 	var executionTree *DashboardExecutionTree
 	defer func() {
 		if err != nil && ctx.Err() != nil {
@@ -65,8 +74,10 @@ func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, das
 
 	// if inputs must be provided before execution (i.e. this is a batch dashboard execution),
 	// verify all required inputs are provided
-	if err = e.validateInputs(executionTree, inputs); err != nil {
-		return err
+	if requireInputs {
+		if err = e.validateInputs(executionTree, inputs); err != nil {
+			return err
+		}
 	}
 
 	// add to execution map
@@ -85,10 +96,6 @@ func (e *DashboardExecutor) ExecuteDashboard(ctx context.Context, sessionId, das
 // if inputs must be provided before execution (i.e. this is a batch dashboard execution),
 // verify all required inputs are provided
 func (e *DashboardExecutor) validateInputs(executionTree *DashboardExecutionTree, inputs map[string]any) error {
-	if e.interactive {
-		// interactive dashboard execution - no need to validate
-		return nil
-	}
 	var missingInputs []string
 	for _, inputName := range executionTree.InputRuntimeDependencies() {
 		if _, ok := inputs[inputName]; !ok {
@@ -102,33 +109,19 @@ func (e *DashboardExecutor) validateInputs(executionTree *DashboardExecutionTree
 	return nil
 }
 
+// LoadSnapshot loads the snapshot with the given name from the workspace or, if it is not found there,
+// from the local snapshot library
 func (e *DashboardExecutor) LoadSnapshot(ctx context.Context, sessionId, snapshotName string, w *workspace.Workspace) (map[string]any, error) {
 	// find snapshot path in workspace
-	snapshotPath, ok := w.GetResourceMaps().Snapshots[snapshotName]
-	if !ok {
-		return nil, fmt.Errorf("snapshot %s not found in %s (%s)", snapshotName, w.Mod.Name(), w.Path)
+	if snapshotPath, ok := w.GetResourceMaps().Snapshots[snapshotName]; ok {
+		return dashboardsnapshot.ReadSnapshotFile(snapshotPath)
 	}
 
-	if !filehelpers.FileExists(snapshotPath) {
-		return nil, fmt.Errorf("snapshot %s not does not exist", snapshotPath)
+	library := dashboardsnapshot.DefaultLibrary()
+	if _, err := library.Get(snapshotName); err != nil {
+		return nil, fmt.Errorf("snapshot %s not found in %s (%s) or the snapshot library (%s)", snapshotName, w.Mod.Name(), w.Path, library.Dir())
 	}
-
-	snapshotContent, err := os.ReadFile(snapshotPath)
-	if err != nil {
-		return nil, err
-	}
-
-	// deserialize the snapshot as an interface map
-	// we cannot deserialize into a SteampipeSnapshot struct
-	// (without custom derserialisation code) as the Panels property is an interface
-	snap := map[string]any{}
-
-	err = json.Unmarshal(snapshotContent, &snap)
-	if err != nil {
-		return nil, err
-	}
-
-	return snap, nil
+	return library.Load(snapshotName)
 }
 
 func (e *DashboardExecutor) OnInputChanged(ctx context.Context, sessionId string, inputs map[string]any, changedInput string) error {
@@ -14,54 +14,74 @@ import (
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/error_helpers"
 	"github.com/turbot/steampipe/pkg/filepaths"
-	"gopkg.in/olahol/melody.v1"
 )
 
-func startAPIAsync(ctx context.Context, webSocket *melody.Melody) chan struct{} {
+// newRouter creates the router for the dashboard assets, the websocket and the REST API
+func newRouter(server *Server, assetsDirectory string) *gin.Engine {
+	router := gin.New()
+	// only add the Recovery middleware
+	router.Use(gin.Recovery())
+	// if auth is configured, authenticate all requests - including the websocket upgrade
+	if len(server.authenticators) > 0 {
+		router.Use(authMiddleware(server.authenticators))
+	}
+
+	router.Use(static.Serve("/", static.LocalFile(assetsDirectory, true)))
+
+	router.GET("/ws", func(c *gin.Context) {
+		server.webSocket.HandleRequest(c.Writer, c.Request)
+	})
+
+	server.registerRESTRoutes(router)
+
+	router.NoRoute(func(c *gin.Context) {
+		// https://stackoverflow.com/questions/49547/how-do-we-control-web-page-caching-across-all-browsers
+		c.Header("Cache-Control", "no-cache, no-store, must-revalidate") // HTTP 1.1.
+		c.Header("Pragma", "no-cache")                                   // HTTP 1.0.
+		c.Header("Expires", "0")                                         // Proxies.
+		c.File(path.Join(assetsDirectory, "index.html"))
+	})
+	return router
+}
+
+func startAPIAsync(ctx context.Context, server *Server) chan struct{} {
 	doneChan := make(chan struct{})
 
 	go func() {
 		gin.SetMode(gin.ReleaseMode)
-		router := gin.New()
-		// only add the Recovery middleware
-		router.Use(gin.Recovery())
-
-		assetsDirectory := filepaths.EnsureDashboardAssetsDir()
-
-		router.Use(static.Serve("/", static.LocalFile(assetsDirectory, true)))
-
-		router.GET("/ws", func(c *gin.Context) {
-			webSocket.HandleRequest(c.Writer, c.Request)
-		})
-
-		router.NoRoute(func(c *gin.Context) {
-			// https://stackoverflow.com/questions/49547/how-do-we-control-web-page-caching-across-all-browsers
-			c.Header("Cache-Control", "no-cache, no-store, must-revalidate") // HTTP 1.1.
-			c.Header("Pragma", "no-cache")                                   // HTTP 1.0.
-			c.Header("Expires", "0")                                         // Proxies.
-			c.File(path.Join(assetsDirectory, "index.html"))
-		})
+		router := newRouter(server, filepaths.EnsureDashboardAssetsDir())
 
 		dashboardServerPort := viper.GetInt(constants.ArgDashboardPort)
 		dashboardServerListen := "localhost"
 		if viper.GetString(constants.ArgDashboardListen) == string(ListenTypeNetwork) {
 			dashboardServerListen = ""
+			if len(server.authenticators) == 0 {
+				OutputWarning(ctx, "Dashboard server is listening on the network with no authentication - set auth_token, auth_username and auth_password or auth_proxy_header in the dashboard options to restrict access")
+			}
 		}
 
 		srv := &http.Server{
-			Addr:    fmt.Sprintf("%s:%d", dashboardServerListen, dashboardServerPort),
-			Handler: router,
+			Addr:      fmt.Sprintf("%s:%d", dashboardServerListen, dashboardServerPort),
+			Handler:   router,
+			TLSConfig: server.tlsConfig,
 		}
 
 		go func() {
 			// service connections
-			if err := srv.ListenAndServe(); err != nil {
+			var err error
+			if server.tlsConfig != nil {
+				// the certificate is set in the TLS config
+				err = srv.ListenAndServeTLS("", "")
+			} else {
+				err = srv.ListenAndServe()
+			}
+			if err != nil {
 				log.Printf("listen: %s\n", err)
 			}
 		}()
 
 		outputReady(ctx, fmt.Sprintf("Dashboard server started on %d and listening on %s", dashboardServerPort, viper.GetString(constants.ArgDashboardListen)))
-		OutputMessage(ctx, fmt.Sprintf("Visit http://localhost:%d", dashboardServerPort))
+		OutputMessage(ctx, fmt.Sprintf("Visit %s://localhost:%d", URLScheme(), dashboardServerPort))
 		OutputMessage(ctx, "Press Ctrl+C to exit")
 		<-ctx.Done()
 		log.Println("Shutdown Server…")
@@ -0,0 +1,185 @@
+package dashboardserver
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+const (
+	// AuthTokenQueryParam is the query parameter a browser may use to pass the auth token, e.g. http://host:9194/?token=xxx
+	AuthTokenQueryParam = "token"
+	// the cookie used to store the auth token, so the assets and websocket requests made by the UI are authenticated
+	authTokenCookie = "steampipe_dashboard_token"
+	authRealm       = "Steampipe Dashboard"
+)
+
+// by default the proxy header is only trusted for requests from the local host
+var defaultTrustedProxies = []string{"127.0.0.1/32", "::1/128"}
+
+// authenticator authenticates requests to the dashboard server
+type authenticator interface {
+	// authenticate returns whether the request has valid credentials
+	authenticate(c *gin.Context) bool
+}
+
+// newAuthenticators creates an authenticator for each auth method set in the dashboard options
+// if no auth method is set, no authenticators are returned and all requests are allowed
+func newAuthenticators() ([]authenticator, error) {
+	var res []authenticator
+
+	if token := viper.GetString(constants.ArgDashboardAuthToken); token != "" {
+		res = append(res, &tokenAuthenticator{token: token})
+	}
+
+	username := viper.GetString(constants.ArgDashboardAuthUsername)
+	password := viper.GetString(constants.ArgDashboardAuthPassword)
+	if username != "" || password != "" {
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("dashboard basic auth requires both a username and a password")
+		}
+		res = append(res, &basicAuthenticator{username: username, password: password})
+	}
+
+	if header := viper.GetString(constants.ArgDashboardAuthProxyHeader); header != "" {
+		trustedProxies := viper.GetStringSlice(constants.ArgDashboardAuthTrustedProxies)
+		if len(trustedProxies) == 0 {
+			trustedProxies = defaultTrustedProxies
+		}
+		proxyAuthenticator, err := newProxyHeaderAuthenticator(header, trustedProxies)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, proxyAuthenticator)
+	}
+
+	return res, nil
+}
+
+// authMiddleware rejects any request (including the websocket upgrade) which is not accepted by one of the authenticators
+func authMiddleware(authenticators []authenticator) gin.HandlerFunc {
+	// if basic auth is enabled, browsers should prompt for credentials
+	promptForCredentials := false
+	for _, a := range authenticators {
+		if _, ok := a.(*basicAuthenticator); ok {
+			promptForCredentials = true
+		}
+	}
+
+	return func(c *gin.Context) {
+		for _, a := range authenticators {
+			if a.authenticate(c) {
+				c.Next()
+				return
+			}
+		}
+		log.Printf("[TRACE] rejecting unauthenticated dashboard request from %s: %s", c.Request.RemoteAddr, c.Request.URL.Path)
+		if promptForCredentials {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Basic realm="%s", charset="UTF-8"`, authRealm))
+		}
+		c.AbortWithStatus(http.StatusUnauthorized)
+	}
+}
+
+// tokenAuthenticator accepts requests with a static bearer token
+//
+// browsers cannot set headers for a link or a websocket, so the token may also be passed as a query parameter -
+// this is then stored in a cookie, so the requests made by the UI are also authenticated
+type tokenAuthenticator struct {
+	token string
+}
+
+func (a *tokenAuthenticator) authenticate(c *gin.Context) bool {
+	if bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && secureCompare(bearer, a.token) {
+		return true
+	}
+	if cookie, err := c.Cookie(authTokenCookie); err == nil && secureCompare(cookie, a.token) {
+		return true
+	}
+	if token := c.Query(AuthTokenQueryParam); token != "" && secureCompare(token, a.token) {
+		c.SetSameSite(http.SameSiteStrictMode)
+		c.SetCookie(authTokenCookie, token, 0, "/", "", c.Request.TLS != nil, true)
+		return true
+	}
+	return false
+}
+
+// basicAuthenticator accepts requests with a static username and password
+type basicAuthenticator struct {
+	username string
+	password string
+}
+
+func (a *basicAuthenticator) authenticate(c *gin.Context) bool {
+	username, password, ok := c.Request.BasicAuth()
+	// compare both values, so the time taken does not show whether the username was correct
+	usernameMatches := secureCompare(username, a.username)
+	passwordMatches := secureCompare(password, a.password)
+	return ok && usernameMatches && passwordMatches
+}
+
+// proxyHeaderAuthenticator trusts a user header set by an authenticating reverse proxy (e.g. an OIDC proxy)
+// the header is only trusted for requests which come from one of the trusted proxy addresses, as it could
+// otherwise be set by anyone
+type proxyHeaderAuthenticator struct {
+	header         string
+	trustedProxies []*net.IPNet
+}
+
+func newProxyHeaderAuthenticator(header string, trustedProxies []string) (*proxyHeaderAuthenticator, error) {
+	res := &proxyHeaderAuthenticator{header: header}
+	for _, proxy := range trustedProxies {
+		// allow single addresses as well as CIDR ranges
+		if !strings.Contains(proxy, "/") {
+			if ip := net.ParseIP(proxy); ip != nil && ip.To4() != nil {
+				proxy += "/32"
+			} else {
+				proxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dashboard trusted proxy '%s' - must be an IP address or CIDR range", proxy)
+		}
+		res.trustedProxies = append(res.trustedProxies, ipNet)
+	}
+	return res, nil
+}
+
+func (a *proxyHeaderAuthenticator) authenticate(c *gin.Context) bool {
+	user := c.GetHeader(a.header)
+	if user == "" || !a.isTrustedProxy(c.Request.RemoteAddr) {
+		return false
+	}
+	log.Printf("[TRACE] dashboard request authenticated by proxy for user %s", user)
+	return true
+}
+
+func (a *proxyHeaderAuthenticator) isTrustedProxy(remoteAddr string) bool {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, trustedProxy := range a.trustedProxies {
+		if trustedProxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// secureCompare compares the values in constant time
+func secureCompare(value, expected string) bool {
+	return subtle.ConstantTimeCompare([]byte(value), []byte(expected)) == 1
+}
@@ -0,0 +1,249 @@
+package dashboardserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type authTest struct {
+	// update the request to add credentials
+	setup      func(r *http.Request)
+	remoteAddr string
+	expected   int
+}
+
+// doAuthRequest makes a request to a route of the dashboard router which does not depend on server state
+func doAuthRequest(t *testing.T, authenticators []authenticator, test authTest) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	router := newRouter(&Server{authenticators: authenticators}, t.TempDir())
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:9194/api/openapi.json", nil)
+	if test.remoteAddr != "" {
+		req.RemoteAddr = test.remoteAddr
+	}
+	if test.setup != nil {
+		test.setup(req)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+var testCasesTokenAuth = map[string]authTest{
+	"no credentials": {expected: http.StatusUnauthorized},
+	"bearer": {
+		setup:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer secret") },
+		expected: http.StatusOK,
+	},
+	"wrong bearer": {
+		setup:    func(r *http.Request) { r.Header.Set("Authorization", "Bearer wrong") },
+		expected: http.StatusUnauthorized,
+	},
+	"cookie": {
+		setup:    func(r *http.Request) { r.AddCookie(&http.Cookie{Name: authTokenCookie, Value: "secret"}) },
+		expected: http.StatusOK,
+	},
+	"wrong cookie": {
+		setup:    func(r *http.Request) { r.AddCookie(&http.Cookie{Name: authTokenCookie, Value: "wrong"}) },
+		expected: http.StatusUnauthorized,
+	},
+	"query": {
+		setup:    func(r *http.Request) { r.URL.RawQuery = AuthTokenQueryParam + "=secret" },
+		expected: http.StatusOK,
+	},
+	"wrong query": {
+		setup:    func(r *http.Request) { r.URL.RawQuery = AuthTokenQueryParam + "=wrong" },
+		expected: http.StatusUnauthorized,
+	},
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	authenticators := []authenticator{&tokenAuthenticator{token: "secret"}}
+	for name, test := range testCasesTokenAuth {
+		w := doAuthRequest(t, authenticators, test)
+		if w.Code != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d", name, test.expected, w.Code)
+		}
+	}
+}
+
+func TestTokenAuthenticatorSetsCookie(t *testing.T) {
+	w := doAuthRequest(t, []authenticator{&tokenAuthenticator{token: "secret"}}, testCasesTokenAuth["query"])
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Name == authTokenCookie && cookie.Value == "secret" && cookie.HttpOnly {
+			return
+		}
+	}
+	t.Errorf("expected the token to be stored in an http only cookie, got %v", w.Result().Cookies())
+}
+
+var testCasesBasicAuth = map[string]authTest{
+	"no credentials": {expected: http.StatusUnauthorized},
+	"valid": {
+		setup:    func(r *http.Request) { r.SetBasicAuth("user", "pass") },
+		expected: http.StatusOK,
+	},
+	"wrong username": {
+		setup:    func(r *http.Request) { r.SetBasicAuth("other", "pass") },
+		expected: http.StatusUnauthorized,
+	},
+	"wrong password": {
+		setup:    func(r *http.Request) { r.SetBasicAuth("user", "wrong") },
+		expected: http.StatusUnauthorized,
+	},
+}
+
+func TestBasicAuthenticator(t *testing.T) {
+	authenticators := []authenticator{&basicAuthenticator{username: "user", password: "pass"}}
+	for name, test := range testCasesBasicAuth {
+		w := doAuthRequest(t, authenticators, test)
+		if w.Code != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d", name, test.expected, w.Code)
+		}
+		// browsers should be prompted for credentials
+		if w.Code == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("Test: '%s'' FAILED : expected a WWW-Authenticate header", name)
+		}
+	}
+}
+
+var testCasesProxyHeaderAuth = map[string]authTest{
+	"trusted proxy": {
+		setup:      func(r *http.Request) { r.Header.Set("X-Forwarded-User", "alice") },
+		remoteAddr: "127.0.0.1:40000",
+		expected:   http.StatusOK,
+	},
+	"trusted ipv6 proxy": {
+		setup:      func(r *http.Request) { r.Header.Set("X-Forwarded-User", "alice") },
+		remoteAddr: "[::1]:40000",
+		expected:   http.StatusOK,
+	},
+	"trusted proxy without header": {
+		remoteAddr: "127.0.0.1:40000",
+		expected:   http.StatusUnauthorized,
+	},
+	"untrusted remote address": {
+		setup:      func(r *http.Request) { r.Header.Set("X-Forwarded-User", "alice") },
+		remoteAddr: "10.0.0.1:40000",
+		expected:   http.StatusUnauthorized,
+	},
+}
+
+func TestProxyHeaderAuthenticator(t *testing.T) {
+	proxyAuthenticator, err := newProxyHeaderAuthenticator("X-Forwarded-User", defaultTrustedProxies)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticators := []authenticator{proxyAuthenticator}
+	for name, test := range testCasesProxyHeaderAuth {
+		w := doAuthRequest(t, authenticators, test)
+		if w.Code != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d", name, test.expected, w.Code)
+		}
+	}
+}
+
+type trustedProxyTest struct {
+	trustedProxy string
+	expected     string
+	expectError  bool
+}
+
+var testCasesTrustedProxies = map[string]trustedProxyTest{
+	"ipv4 address": {trustedProxy: "10.1.2.3", expected: "10.1.2.3/32"},
+	"ipv6 address": {trustedProxy: "fd00::1", expected: "fd00::1/128"},
+	"ipv4 range":   {trustedProxy: "10.0.0.0/8", expected: "10.0.0.0/8"},
+	"ipv6 range":   {trustedProxy: "fd00::/8", expected: "fd00::/8"},
+	"invalid":      {trustedProxy: "proxy.local", expectError: true},
+}
+
+func TestNewProxyHeaderAuthenticator(t *testing.T) {
+	for name, test := range testCasesTrustedProxies {
+		res, err := newProxyHeaderAuthenticator("X-Forwarded-User", []string{test.trustedProxy})
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+		if actual := res.trustedProxies[0].String(); actual != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, actual)
+		}
+	}
+}
+
+func TestWebsocketUpgradeRequiresAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newRouter(&Server{authenticators: []authenticator{&tokenAuthenticator{token: "secret"}}}, t.TempDir())
+
+	req := httptest.NewRequest(http.MethodGet, "/ws", nil)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "websocket")
+	req.Header.Set("Sec-WebSocket-Version", "13")
+	req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusUnauthorized {
+		t.Errorf("expected status %d, got %d", http.StatusUnauthorized, w.Code)
+	}
+}
+
+type websocketOriginTest struct {
+	origin   string
+	expected int
+}
+
+var testCasesWebsocketOrigin = map[string]websocketOriginTest{
+	"no origin":    {expected: http.StatusSwitchingProtocols},
+	"same origin":  {origin: "same", expected: http.StatusSwitchingProtocols},
+	"cross origin": {origin: "http://attacker.example.com", expected: http.StatusForbidden},
+}
+
+// browsers send cached basic auth credentials with cross-site websocket upgrades, so the origin must also be checked
+func TestWebsocketUpgradeRejectsCrossOrigin(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	server := &Server{
+		authenticators: []authenticator{&basicAuthenticator{username: "user", password: "pass"}},
+		webSocket:      newWebSocket(),
+	}
+	// the upgrade must be served over a real connection, as the response recorder cannot be hijacked
+	testServer := httptest.NewServer(newRouter(server, t.TempDir()))
+	defer testServer.Close()
+
+	for name, test := range testCasesWebsocketOrigin {
+		req, err := http.NewRequest(http.MethodGet, testServer.URL+"/ws", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.SetBasicAuth("user", "pass")
+		req.Header.Set("Connection", "Upgrade")
+		req.Header.Set("Upgrade", "websocket")
+		req.Header.Set("Sec-WebSocket-Version", "13")
+		req.Header.Set("Sec-WebSocket-Key", "dGhlIHNhbXBsZSBub25jZQ==")
+		switch test.origin {
+		case "":
+		case "same":
+			req.Header.Set("Origin", testServer.URL)
+		default:
+			req.Header.Set("Origin", test.origin)
+		}
+
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+		res.Body.Close()
+		if res.StatusCode != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d", name, test.expected, res.StatusCode)
+		}
+	}
+}
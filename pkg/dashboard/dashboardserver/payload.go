@@ -8,9 +8,11 @@ import (
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardsnapshot"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/version"
+	"log"
 )
 
 func buildDashboardMetadataPayload(workspaceResources *modconfig.ResourceMaps, cloudMetadata *steampipeconfig.CloudMetadata) ([]byte, error) {
@@ -77,13 +79,32 @@ func addBenchmarkChildren(benchmark *modconfig.Benchmark, recordTrunk bool, trun
 	return children
 }
 
-func buildAvailableDashboardsPayload(workspaceResources *modconfig.ResourceMaps) ([]byte, error) {
+func buildAvailableDashboardsPayload(workspaceResources *modconfig.ResourceMaps, library *dashboardsnapshot.Library) ([]byte, error) {
+	return json.Marshal(buildAvailableDashboards(workspaceResources, library))
+}
 
+// buildAvailableDashboards builds the dashboards and benchmarks available in the workspace, and the snapshots
+// available in the workspace and the snapshot library (if given)
+func buildAvailableDashboards(workspaceResources *modconfig.ResourceMaps, library *dashboardsnapshot.Library) AvailableDashboardsPayload {
 	payload := AvailableDashboardsPayload{
 		Action:     "available_dashboards",
 		Dashboards: make(map[string]ModAvailableDashboard),
 		Benchmarks: make(map[string]ModAvailableBenchmark),
-		Snapshots:  workspaceResources.Snapshots,
+		Snapshots:  make(map[string]string),
+	}
+
+	if library != nil {
+		librarySnapshots, err := library.List(nil)
+		if err != nil {
+			log.Printf("[WARN] failed to list the snapshot library: %s", err.Error())
+		}
+		for _, info := range librarySnapshots {
+			payload.Snapshots[info.Name] = library.Path(info)
+		}
+	}
+	// workspace snapshots take precedence over library snapshots with the same name
+	for name, snapshotPath := range workspaceResources.Snapshots {
+		payload.Snapshots[name] = snapshotPath
 	}
 
 	// if workspace resources has a mod, populate dashboards and benchmarks
@@ -145,7 +166,7 @@ func buildAvailableDashboardsPayload(workspaceResources *modconfig.ResourceMaps)
 		}
 	}
 
-	return json.Marshal(payload)
+	return payload
 }
 
 func buildWorkspaceErrorPayload(e *dashboardevents.WorkspaceError) ([]byte, error) {
@@ -234,6 +255,22 @@ func buildDisplaySnapshotPayload(snap map[string]any) ([]byte, error) {
 	return json.Marshal(payload)
 }
 
+func buildAvailableSnapshotsPayload(snapshots []*dashboardsnapshot.SnapshotInfo) ([]byte, error) {
+	payload := AvailableSnapshotsPayload{
+		Action:    "available_snapshots",
+		Snapshots: snapshots,
+	}
+	return json.Marshal(payload)
+}
+
+func buildSnapshotErrorPayload(err error) ([]byte, error) {
+	payload := ErrorPayload{
+		Action: "snapshot_error",
+		Error:  err.Error(),
+	}
+	return json.Marshal(payload)
+}
+
 func buildInputValuesClearedPayload(event *dashboardevents.InputValuesCleared) ([]byte, error) {
 	payload := InputValuesClearedPayload{
 		Action:        "input_values_cleared",
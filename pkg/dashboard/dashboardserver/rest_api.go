@@ -0,0 +1,330 @@
+package dashboardserver
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardsnapshot"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// the OpenAPI document describing the REST API
+//
+//go:embed openapi.json
+var openAPIDocument []byte
+
+// the prefix of the session id of dashboards and benchmarks run using the REST API
+const apiSessionPrefix = "api_"
+
+type apiRunRequest struct {
+	// map of input name to value - names may be given with or without the 'input.' prefix
+	Inputs map[string]any `json:"inputs"`
+}
+
+type apiQueryRequest struct {
+	SQL  string `json:"sql"`
+	Args []any  `json:"args"`
+}
+
+type apiQueryColumn struct {
+	Name     string `json:"name"`
+	DataType string `json:"data_type"`
+}
+
+type apiQueryResponse struct {
+	Columns  []apiQueryColumn          `json:"columns"`
+	Rows     []map[string]any          `json:"rows"`
+	Metadata *queryresult.TimingResult `json:"metadata,omitempty"`
+}
+
+type apiSchedule struct {
+	*modconfig.Schedule
+	// schedules are only run when the dashboard server is running as a service
+	Active  bool         `json:"active"`
+	LastRun *ScheduleRun `json:"last_run,omitempty"`
+}
+
+type apiErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// registerRESTRoutes adds the REST API routes, which allow dashboards, benchmarks and queries
+// to be run over plain HTTP rather than the websocket protocol used by the UI
+func (s *Server) registerRESTRoutes(router *gin.Engine) {
+	api := router.Group("/api")
+	api.Use(apiGuard(apiAllowedHosts()))
+	api.GET("/openapi.json", s.getOpenAPIDocument)
+	api.GET("/dashboards", s.listDashboards)
+	api.POST("/dashboards/:name/run", s.runDashboard)
+	api.GET("/benchmarks", s.listBenchmarks)
+	api.POST("/check/:benchmark", s.runBenchmark)
+	api.POST("/query", s.runQuery)
+	api.GET("/snapshots", s.listSnapshots)
+	api.GET("/snapshots/:name", s.getSnapshot)
+	api.DELETE("/snapshots/:name", s.deleteSnapshot)
+	api.GET("/schedules", s.listSchedules)
+	api.GET("/schedules/:name/runs", s.getScheduleRuns)
+}
+
+func (s *Server) getOpenAPIDocument(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json", openAPIDocument)
+}
+
+func (s *Server) listDashboards(c *gin.Context) {
+	c.JSON(http.StatusOK, buildAvailableDashboards(s.workspace.GetResourceMaps(), nil).Dashboards)
+}
+
+func (s *Server) listBenchmarks(c *gin.Context) {
+	c.JSON(http.StatusOK, buildAvailableDashboards(s.workspace.GetResourceMaps(), nil).Benchmarks)
+}
+
+// runDashboard runs a dashboard with the inputs given in the request body, returning the snapshot
+func (s *Server) runDashboard(c *gin.Context) {
+	var request apiRunRequest
+	if err := bindOptionalJSON(c, &request); err != nil {
+		apiError(c, http.StatusBadRequest, err)
+		return
+	}
+	s.runTarget(c, c.Param("name"), modconfig.BlockTypeDashboard, request.Inputs)
+}
+
+// runBenchmark runs a benchmark, returning the snapshot
+func (s *Server) runBenchmark(c *gin.Context) {
+	s.runTarget(c, c.Param("benchmark"), modconfig.BlockTypeBenchmark, nil)
+}
+
+// runTarget executes the dashboard or benchmark, returning the snapshot
+func (s *Server) runTarget(c *gin.Context, name, blockType string, inputs map[string]any) {
+	ctx := c.Request.Context()
+
+	target, err := s.resolveTarget(name, blockType)
+	if err != nil {
+		apiError(c, http.StatusNotFound, err)
+		return
+	}
+
+	snapshot, err := s.executeTarget(ctx, apiSessionPrefix+uuid.NewString(), target, apiInputs(inputs))
+	if err != nil {
+		if error_helpers.IsContextCanceled(ctx) {
+			// the client has gone away - there is no one to respond to
+			return
+		}
+		status := http.StatusInternalServerError
+		var startErr runStartError
+		if errors.As(err, &startErr) {
+			status = http.StatusBadRequest
+		}
+		apiError(c, status, err)
+		return
+	}
+	c.JSON(http.StatusOK, snapshot)
+}
+
+// resolveTarget returns the full name of the dashboard or benchmark with the given name, which may be
+// the short name, the resource name (e.g. dashboard.my_dashboard) or the full name including the mod
+func (s *Server) resolveTarget(name, blockType string) (string, error) {
+	if !strings.Contains(name, ".") {
+		name = modconfig.BuildModResourceName(blockType, name)
+	}
+	parsedName, err := modconfig.ParseResourceName(name)
+	if err != nil || parsedName.ItemType != blockType {
+		return "", fmt.Errorf("'%s' is not a valid %s name", name, blockType)
+	}
+	resource, found := s.workspace.GetResourceMaps().GetResource(parsedName)
+	if !found {
+		return "", fmt.Errorf("%s '%s' does not exist in workspace", blockType, name)
+	}
+	return resource.Name(), nil
+}
+
+// runQuery executes the SQL in the request body, returning the columns and rows
+// as this allows any SQL to be run, it is only enabled if the server requires authentication, or it is enabled explicitly
+func (s *Server) runQuery(c *gin.Context) {
+	if len(s.authenticators) == 0 && !viper.GetBool(constants.ArgDashboardEnableQueryAPI) {
+		apiError(c, http.StatusForbidden, fmt.Errorf("the query API is disabled - configure dashboard authentication or set enable_query_api in the dashboard options to enable it"))
+		return
+	}
+	var request apiQueryRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		apiError(c, http.StatusBadRequest, err)
+		return
+	}
+	if strings.TrimSpace(request.SQL) == "" {
+		apiError(c, http.StatusBadRequest, fmt.Errorf("sql must be provided"))
+		return
+	}
+
+	result, err := s.dbClient.ExecuteSync(c.Request.Context(), request.SQL, request.Args...)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, error_helpers.DecodePgError(err))
+		return
+	}
+
+	response := apiQueryResponse{
+		Columns:  make([]apiQueryColumn, len(result.Cols)),
+		Rows:     make([]map[string]any, 0, len(result.Rows)),
+		Metadata: result.TimingResult,
+	}
+	for i, col := range result.Cols {
+		response.Columns[i] = apiQueryColumn{Name: col.Name, DataType: col.DataType}
+	}
+	for _, r := range result.Rows {
+		row, ok := r.(*queryresult.RowResult)
+		if !ok {
+			continue
+		}
+		record := make(map[string]any, len(result.Cols))
+		for i, col := range result.Cols {
+			record[col.Name], _ = display.ParseJSONOutputColumnValue(row.Data[i], col)
+		}
+		response.Rows = append(response.Rows, record)
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// listSnapshots returns the snapshots in the snapshot library, filtered by the search, tag, from and to query parameters
+func (s *Server) listSnapshots(c *gin.Context) {
+	filter, err := apiSnapshotFilter(c)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, err)
+		return
+	}
+	snapshots, err := s.snapshotLibrary.List(filter)
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, err)
+		return
+	}
+	if snapshots == nil {
+		snapshots = []*dashboardsnapshot.SnapshotInfo{}
+	}
+	c.JSON(http.StatusOK, snapshots)
+}
+
+// getSnapshot returns the contents of a snapshot in the snapshot library
+func (s *Server) getSnapshot(c *gin.Context) {
+	info, err := s.snapshotLibrary.Get(apiSnapshotName(c.Param("name")))
+	if err != nil {
+		apiError(c, http.StatusNotFound, err)
+		return
+	}
+	snap, err := dashboardsnapshot.ReadSnapshotFile(s.snapshotLibrary.Path(info))
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, err)
+		return
+	}
+	c.JSON(http.StatusOK, snap)
+}
+
+// deleteSnapshot deletes a snapshot from the snapshot library
+func (s *Server) deleteSnapshot(c *gin.Context) {
+	snapshotName := apiSnapshotName(c.Param("name"))
+	if _, err := s.snapshotLibrary.Get(snapshotName); err != nil {
+		apiError(c, http.StatusNotFound, err)
+		return
+	}
+	if err := s.snapshotLibrary.Delete(snapshotName); err != nil {
+		apiError(c, http.StatusInternalServerError, err)
+		return
+	}
+	OutputMessage(c.Request.Context(), fmt.Sprintf("Snapshot deleted: %s", snapshotName))
+	c.Status(http.StatusNoContent)
+}
+
+// listSchedules returns the schedules defined in the config, with their most recent run
+func (s *Server) listSchedules(c *gin.Context) {
+	res := []apiSchedule{}
+	for _, schedule := range steampipeconfig.GlobalConfig.Schedules {
+		history, err := loadScheduleHistory(schedule.Name)
+		if err != nil {
+			apiError(c, http.StatusInternalServerError, err)
+			return
+		}
+		apiSchedule := apiSchedule{Schedule: schedule, Active: s.scheduler != nil}
+		if len(history) > 0 {
+			apiSchedule.LastRun = history[len(history)-1]
+		}
+		res = append(res, apiSchedule)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+	c.JSON(http.StatusOK, res)
+}
+
+// getScheduleRuns returns the run history of a schedule, most recent first
+func (s *Server) getScheduleRuns(c *gin.Context) {
+	name := c.Param("name")
+	if _, ok := steampipeconfig.GlobalConfig.Schedules[name]; !ok {
+		apiError(c, http.StatusNotFound, fmt.Errorf("schedule '%s' does not exist", name))
+		return
+	}
+	history, err := loadScheduleHistory(name)
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, err)
+		return
+	}
+	res := make([]*ScheduleRun, len(history))
+	for i, scheduleRun := range history {
+		res[len(history)-1-i] = scheduleRun
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+// apiSnapshotFilter builds the snapshot filter from the query parameters
+// tags are given as tag=key=value, and may be repeated - dates may be RFC 3339 timestamps or yyyy-mm-dd
+func apiSnapshotFilter(c *gin.Context) (*dashboardsnapshot.Filter, error) {
+	tags := make(map[string]string)
+	for _, tag := range c.QueryArray("tag") {
+		key, value, ok := strings.Cut(tag, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid tag '%s' - tags must be specified as key=value", tag)
+		}
+		tags[key] = value
+	}
+	return dashboardsnapshot.NewFilter(c.Query("search"), tags, c.Query("from"), c.Query("to"))
+}
+
+// apiSnapshotName adds the 'snapshot.' prefix to the snapshot name if it does not have it
+func apiSnapshotName(name string) string {
+	if !strings.HasPrefix(name, modconfig.ResourceTypeSnapshot+".") {
+		name = modconfig.BuildModResourceName(modconfig.ResourceTypeSnapshot, name)
+	}
+	return name
+}
+
+// apiInputs adds the 'input.' prefix to any input names which do not have it
+func apiInputs(inputs map[string]any) map[string]any {
+	res := make(map[string]any, len(inputs))
+	for name, value := range inputs {
+		if !strings.HasPrefix(name, modconfig.BlockTypeInput+".") {
+			name = modconfig.BuildModResourceName(modconfig.BlockTypeInput, name)
+		}
+		res[name] = value
+	}
+	return res
+}
+
+// bindOptionalJSON binds the request body to the target, if there is one
+func bindOptionalJSON(c *gin.Context, target any) error {
+	if err := c.ShouldBindJSON(target); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
+func apiError(c *gin.Context, status int, err error) {
+	c.AbortWithStatusJSON(status, apiErrorResponse{Error: err.Error()})
+}
@@ -0,0 +1,130 @@
+package dashboardserver
+
+import (
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// apiGuard protects the REST API from requests made by other web sites in the user's browser:
+//   - cross-site requests (CSRF) are rejected using the Origin header, which browsers set for cross-origin requests
+//   - DNS rebinding is rejected using the Host header, which must be one of the addresses the server listens on
+//   - request bodies must be JSON, which a cross-site form cannot send
+func apiGuard(allowedHosts map[string]struct{}) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if host := hostName(c.Request.Host); !isAllowedHost(allowedHosts, host) {
+			log.Printf("[TRACE] rejecting dashboard API request for host %s from %s", c.Request.Host, c.Request.RemoteAddr)
+			apiError(c, http.StatusForbidden, fmt.Errorf("host '%s' is not allowed", host))
+			return
+		}
+		if origin := c.GetHeader("Origin"); origin != "" && !isSameOrigin(origin, c.Request.Host) {
+			log.Printf("[TRACE] rejecting cross-origin dashboard API request from %s", origin)
+			apiError(c, http.StatusForbidden, fmt.Errorf("cross-origin requests are not allowed"))
+			return
+		}
+		if err := validateJSONBody(c.Request); err != nil {
+			apiError(c, http.StatusUnsupportedMediaType, err)
+			return
+		}
+		c.Next()
+	}
+}
+
+// websocketOriginCheck returns the origin check for websocket upgrades - as with the REST API, the host must be one
+// of the allowed hosts and requests made by browsers must come from the dashboard itself
+//
+// browsers do not apply the same origin policy to websockets, and send any cached basic auth credentials or cookies
+// with the upgrade request, so without this any web site could open a websocket to the dashboard server
+func websocketOriginCheck(allowedHosts map[string]struct{}) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		if host := hostName(r.Host); !isAllowedHost(allowedHosts, host) {
+			log.Printf("[TRACE] rejecting dashboard websocket request for host %s from %s", r.Host, r.RemoteAddr)
+			return false
+		}
+		if origin := r.Header.Get("Origin"); origin != "" && !isSameOrigin(origin, r.Host) {
+			log.Printf("[TRACE] rejecting cross-origin dashboard websocket request from %s", origin)
+			return false
+		}
+		return true
+	}
+}
+
+// apiAllowedHosts returns the host names the REST API may be accessed by - these are the loopback addresses and,
+// if the server is listening on the network, the addresses and host name of this machine
+func apiAllowedHosts() map[string]struct{} {
+	res := map[string]struct{}{
+		"localhost": {},
+	}
+	if viper.GetString(constants.ArgDashboardListen) != string(ListenTypeNetwork) {
+		return res
+	}
+
+	if hostname, err := os.Hostname(); err == nil {
+		res[strings.ToLower(hostname)] = struct{}{}
+	}
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		log.Printf("[WARN] failed to list the network addresses of this machine: %s", err.Error())
+		return res
+	}
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok {
+			res[ipNet.IP.String()] = struct{}{}
+		}
+	}
+	return res
+}
+
+func isAllowedHost(allowedHosts map[string]struct{}, host string) bool {
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() {
+			return true
+		}
+		host = ip.String()
+	}
+	_, ok := allowedHosts[host]
+	return ok
+}
+
+// hostName returns the lower case host of a Host header, without the port or IPv6 brackets
+func hostName(hostHeader string) string {
+	host, _, err := net.SplitHostPort(hostHeader)
+	if err != nil {
+		host = strings.TrimSuffix(strings.TrimPrefix(hostHeader, "["), "]")
+	}
+	return strings.TrimSuffix(strings.ToLower(host), ".")
+}
+
+// isSameOrigin returns whether the origin is the host the request was made to
+func isSameOrigin(origin, hostHeader string) bool {
+	originURL, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(originURL.Host, hostHeader)
+}
+
+// validateJSONBody returns an error if the request has a body which is not JSON
+func validateJSONBody(r *http.Request) error {
+	contentType := r.Header.Get("Content-Type")
+	if contentType == "" {
+		if r.ContentLength != 0 {
+			return fmt.Errorf("the request body must be application/json")
+		}
+		return nil
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != "application/json" {
+		return fmt.Errorf("unsupported content type '%s' - the request body must be application/json", contentType)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package dashboardserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type apiGuardTest struct {
+	method      string
+	url         string
+	origin      string
+	contentType string
+	body        string
+	expected    int
+}
+
+var testCasesAPIGuard = map[string]apiGuardTest{
+	"localhost": {
+		method:   http.MethodGet,
+		url:      "http://localhost:9194/api/openapi.json",
+		expected: http.StatusOK,
+	},
+	"loopback ipv4": {
+		method:   http.MethodGet,
+		url:      "http://127.0.0.1:9194/api/openapi.json",
+		expected: http.StatusOK,
+	},
+	"loopback ipv6": {
+		method:   http.MethodGet,
+		url:      "http://[::1]:9194/api/openapi.json",
+		expected: http.StatusOK,
+	},
+	"dns rebinding": {
+		method:   http.MethodGet,
+		url:      "http://attacker.example.com:9194/api/openapi.json",
+		expected: http.StatusForbidden,
+	},
+	"same origin": {
+		method:   http.MethodGet,
+		url:      "http://localhost:9194/api/openapi.json",
+		origin:   "http://localhost:9194",
+		expected: http.StatusOK,
+	},
+	"cross origin": {
+		method:   http.MethodGet,
+		url:      "http://localhost:9194/api/openapi.json",
+		origin:   "http://attacker.example.com",
+		expected: http.StatusForbidden,
+	},
+	"form body": {
+		method:      http.MethodPost,
+		url:         "http://localhost:9194/api/query",
+		contentType: "application/x-www-form-urlencoded",
+		body:        "sql=select 1",
+		expected:    http.StatusUnsupportedMediaType,
+	},
+	"text body": {
+		method:      http.MethodPost,
+		url:         "http://localhost:9194/api/query",
+		contentType: "text/plain",
+		body:        `{"sql": "select 1"}`,
+		expected:    http.StatusUnsupportedMediaType,
+	},
+	"body without content type": {
+		method:   http.MethodPost,
+		url:      "http://localhost:9194/api/query",
+		body:     `{"sql": "select 1"}`,
+		expected: http.StatusUnsupportedMediaType,
+	},
+	// the query api is disabled, as there is no authentication
+	"json body": {
+		method:      http.MethodPost,
+		url:         "http://localhost:9194/api/query",
+		contentType: "application/json; charset=utf-8",
+		body:        `{"sql": "select 1"}`,
+		expected:    http.StatusForbidden,
+	},
+}
+
+func TestAPIGuard(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := newRouter(&Server{}, t.TempDir())
+
+	for name, test := range testCasesAPIGuard {
+		req := httptest.NewRequest(test.method, test.url, strings.NewReader(test.body))
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		if test.contentType != "" {
+			req.Header.Set("Content-Type", test.contentType)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		if w.Code != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected status %d, got %d: %s", name, test.expected, w.Code, w.Body.String())
+		}
+	}
+}
+
+type websocketOriginCheckTest struct {
+	url      string
+	origin   string
+	expected bool
+}
+
+// with no authentication configured, the origin check is all that stops other web sites from opening a websocket
+// and sending destructive requests, such as delete_snapshot
+var testCasesWebsocketOriginCheck = map[string]websocketOriginCheckTest{
+	"no origin": {
+		url:      "http://localhost:9194/ws",
+		expected: true,
+	},
+	"same origin": {
+		url:      "http://localhost:9194/ws",
+		origin:   "http://localhost:9194",
+		expected: true,
+	},
+	"same origin loopback": {
+		url:      "http://127.0.0.1:9194/ws",
+		origin:   "http://127.0.0.1:9194",
+		expected: true,
+	},
+	"cross origin": {
+		url:      "http://localhost:9194/ws",
+		origin:   "http://attacker.example.com",
+		expected: false,
+	},
+	"different port": {
+		url:      "http://localhost:9194/ws",
+		origin:   "http://localhost:3000",
+		expected: false,
+	},
+	"dns rebinding": {
+		url:      "http://attacker.example.com:9194/ws",
+		origin:   "http://attacker.example.com:9194",
+		expected: false,
+	},
+}
+
+func TestWebsocketOriginCheck(t *testing.T) {
+	checkOrigin := websocketOriginCheck(apiAllowedHosts())
+	for name, test := range testCasesWebsocketOriginCheck {
+		req := httptest.NewRequest(http.MethodGet, test.url, nil)
+		if test.origin != "" {
+			req.Header.Set("Origin", test.origin)
+		}
+		if actual := checkOrigin(req); actual != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package dashboardserver
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardevents"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// runResult is the result of a dashboard or benchmark run in a session of its own, i.e. a run
+// started by the REST API or a schedule rather than by the UI
+type runResult struct {
+	snapshot *dashboardtypes.SteampipeSnapshot
+	err      error
+}
+
+// runStartError is returned by executeTarget if the execution could not be started, e.g. because of invalid inputs
+type runStartError struct {
+	error
+}
+
+func (e runStartError) Unwrap() error {
+	return e.error
+}
+
+// executeTarget executes the dashboard or benchmark in a session with the given id, and waits for the snapshot
+// if the context is cancelled, the execution is cancelled
+func (s *Server) executeTarget(ctx context.Context, sessionId, target string, inputs map[string]any) (*dashboardtypes.SteampipeSnapshot, error) {
+	resultChan := s.addRun(sessionId)
+	defer s.removeRun(sessionId)
+
+	err := dashboardexecute.Executor.ExecuteDashboardWithInputs(ctx, sessionId, target, inputs, s.workspace, s.dbClient)
+	if err != nil {
+		return nil, runStartError{err}
+	}
+	// remove the execution once it is complete, cancelling it if it is still running
+	defer dashboardexecute.Executor.CancelExecutionForSession(ctx, sessionId)
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case result := <-resultChan:
+		if result.err != nil {
+			return nil, result.err
+		}
+		// set the filename root of the snapshot, used to name its exports
+		if result.snapshot.FileNameRoot, err = s.fileNameRoot(target); err != nil {
+			return nil, err
+		}
+		return result.snapshot, nil
+	}
+}
+
+// fileNameRoot returns the root of the file name of the exports of the target,
+// i.e. the full name of the target, including the mod
+func (s *Server) fileNameRoot(target string) (string, error) {
+	parsedName, err := modconfig.ParseResourceName(target)
+	if err != nil {
+		return "", err
+	}
+	return parsedName.ToFullNameWithMod(s.workspace.Mod.ShortName)
+}
+
+// handleRunEvent returns the snapshot (or error) of runs started by executeTarget when their execution completes
+// NOTE: this is called from the workspace event handler goroutine, so must not block
+func (s *Server) handleRunEvent(_ context.Context, event dashboardevents.DashboardEvent) {
+	switch e := event.(type) {
+	case *dashboardevents.ExecutionError:
+		s.completeRun(e.Session, &runResult{err: e.Error})
+	case *dashboardevents.ExecutionComplete:
+		s.completeRun(e.Session, &runResult{snapshot: dashboardexecute.ExecutionCompleteToSnapshot(e)})
+	}
+}
+
+func (s *Server) addRun(sessionId string) chan *runResult {
+	s.runsMutex.Lock()
+	defer s.runsMutex.Unlock()
+
+	// buffer the channel so the event handler never blocks
+	resultChan := make(chan *runResult, 1)
+	s.runs[sessionId] = resultChan
+	return resultChan
+}
+
+func (s *Server) removeRun(sessionId string) {
+	s.runsMutex.Lock()
+	defer s.runsMutex.Unlock()
+
+	delete(s.runs, sessionId)
+}
+
+func (s *Server) completeRun(sessionId string, result *runResult) {
+	s.runsMutex.Lock()
+	defer s.runsMutex.Unlock()
+
+	// only the first result of a run is returned
+	if resultChan, ok := s.runs[sessionId]; ok {
+		resultChan <- result
+		delete(s.runs, sessionId)
+	}
+}
@@ -0,0 +1,29 @@
+package dashboardserver
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/workspace"
+)
+
+var testCasesFileNameRoot = map[string]string{
+	"local.dashboard.d1":          "local.dashboard.d1",
+	"dashboard.d1":                "local.dashboard.d1",
+	"aws_compliance.benchmark.b1": "aws_compliance.benchmark.b1",
+}
+
+func TestFileNameRoot(t *testing.T) {
+	s := &Server{workspace: &workspace.Workspace{Mod: modconfig.NewMod("local", "", hcl.Range{})}}
+	for target, expected := range testCasesFileNameRoot {
+		actual, err := s.fileNameRoot(target)
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", target, err.Error())
+			continue
+		}
+		if actual != expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", target, expected, actual)
+		}
+	}
+}
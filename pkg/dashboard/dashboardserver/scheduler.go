@@ -0,0 +1,292 @@
+package dashboardserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/robfig/cron/v3"
+	filehelpers "github.com/turbot/go-kit/files"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/control/controldisplay"
+	"github.com/turbot/steampipe/pkg/control/controlexecute"
+	"github.com/turbot/steampipe/pkg/export"
+	"github.com/turbot/steampipe/pkg/filepaths"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// the prefix of the session id of scheduled dashboard and benchmark runs
+const scheduleSessionPrefix = "schedule_"
+
+const (
+	ScheduleRunStatusComplete = "complete"
+	ScheduleRunStatusError    = "error"
+)
+
+// ScheduleRun is a run of a schedule, as stored in the schedule run history
+type ScheduleRun struct {
+	Schedule  string    `json:"schedule"`
+	Target    string    `json:"target"`
+	StartTime time.Time `json:"start_time"`
+	EndTime   time.Time `json:"end_time"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	// the paths of the exported files
+	Outputs []string `json:"outputs,omitempty"`
+}
+
+// scheduler runs the dashboards and benchmarks of the schedule blocks in the config, on their cron schedules
+type scheduler struct {
+	server    *Server
+	schedules map[string]*modconfig.Schedule
+	cron      *cron.Cron
+	// dashboards may only be exported as snapshots, whereas benchmarks are run as a check,
+	// so may be exported in any of the check export formats
+	dashboardExportManager *export.Manager
+	checkExportManager     *export.Manager
+	ctx                    context.Context
+	cancel                 context.CancelFunc
+	// the names of the schedules which are currently running
+	running      map[string]bool
+	runningMutex sync.Mutex
+}
+
+func newScheduler(ctx context.Context, server *Server, schedules map[string]*modconfig.Schedule) (*scheduler, error) {
+	dashboardExportManager := export.NewManager()
+	if err := dashboardExportManager.Register(&export.SnapshotExporter{}); err != nil {
+		return nil, err
+	}
+	checkExporters, err := controldisplay.GetExporters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	checkExportManager := export.NewManager()
+	for _, exporter := range checkExporters {
+		if err := checkExportManager.Register(exporter); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &scheduler{
+		server:                 server,
+		schedules:              schedules,
+		cron:                   cron.New(),
+		dashboardExportManager: dashboardExportManager,
+		checkExportManager:     checkExportManager,
+		running:                make(map[string]bool),
+	}
+	for _, schedule := range schedules {
+		if err := s.exportManager(schedule).ValidateExportFormat(schedule.Export); err != nil {
+			return nil, fmt.Errorf("invalid export for schedule '%s': %s", schedule.Name, err.Error())
+		}
+		// copy the loop variable, as it is captured by the closure
+		schedule := schedule
+		if _, err := s.cron.AddFunc(schedule.Cron, func() { s.run(schedule) }); err != nil {
+			return nil, fmt.Errorf("invalid cron expression '%s' for schedule '%s': %s", schedule.Cron, schedule.Name, err.Error())
+		}
+	}
+	return s, nil
+}
+
+// start starts running the schedules in the background
+func (s *scheduler) start(ctx context.Context) {
+	s.ctx, s.cancel = context.WithCancel(ctx)
+	s.cron.Start()
+	OutputMessage(ctx, fmt.Sprintf("Scheduler started with %d %s", len(s.schedules), utils.Pluralize("schedule", len(s.schedules))))
+}
+
+// stop stops the scheduler, cancelling any runs in progress and waiting for them to finish
+func (s *scheduler) stop() {
+	if s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.cron.Stop().Done()
+}
+
+// run executes the schedule target, exports the results and adds the run to the schedule run history
+func (s *scheduler) run(schedule *modconfig.Schedule) {
+	ctx := s.ctx
+	if !s.setRunning(schedule.Name) {
+		OutputWarning(ctx, fmt.Sprintf("Skipping scheduled run of '%s' - the previous run is still in progress", schedule.Name))
+		return
+	}
+	defer s.clearRunning(schedule.Name)
+
+	OutputWait(ctx, fmt.Sprintf("Scheduled run started: %s (%s)", schedule.Name, schedule.Target))
+	scheduleRun := &ScheduleRun{
+		Schedule:  schedule.Name,
+		Target:    schedule.Target,
+		StartTime: time.Now(),
+		Status:    ScheduleRunStatusComplete,
+	}
+
+	outputs, err := s.execute(ctx, schedule)
+	scheduleRun.EndTime = time.Now()
+	scheduleRun.Outputs = outputs
+	if err != nil {
+		scheduleRun.Status = ScheduleRunStatusError
+		scheduleRun.Error = err.Error()
+		OutputError(ctx, fmt.Errorf("scheduled run of '%s' failed: %s", schedule.Name, err.Error()))
+	} else {
+		OutputMessage(ctx, fmt.Sprintf("Scheduled run complete: %s - exported to %s", schedule.Name, strings.Join(outputs, ", ")))
+	}
+
+	if err := appendScheduleHistory(scheduleRun); err != nil {
+		log.Printf("[WARN] failed to save the run history of schedule '%s': %s", schedule.Name, err.Error())
+	}
+}
+
+// execute runs the schedule target in a session of its own, and exports the results
+// it returns the paths of the exported files
+func (s *scheduler) execute(ctx context.Context, schedule *modconfig.Schedule) ([]string, error) {
+	// the target is validated when the config is loaded, but may have been removed from the workspace since
+	parsedName, err := modconfig.ParseResourceName(schedule.Target)
+	if err != nil {
+		return nil, err
+	}
+	target, err := s.server.resolveTarget(schedule.Target, parsedName.ItemType)
+	if err != nil {
+		return nil, err
+	}
+
+	var source export.ExportSourceData
+	var fileNameRoot string
+	if parsedName.ItemType == modconfig.BlockTypeBenchmark {
+		tree, err := s.executeBenchmark(ctx, target)
+		if err != nil {
+			return nil, err
+		}
+		if fileNameRoot, err = s.server.fileNameRoot(target); err != nil {
+			return nil, err
+		}
+		source = tree
+	} else {
+		snapshot, err := s.server.executeTarget(ctx, scheduleSessionPrefix+uuid.NewString(), target, schedule.InputValues())
+		if err != nil {
+			return nil, err
+		}
+		fileNameRoot = snapshot.FileNameRoot
+		source = snapshot
+	}
+
+	return s.export(ctx, schedule, fileNameRoot, source)
+}
+
+// executeBenchmark runs the benchmark as a check, returning the execution tree
+func (s *scheduler) executeBenchmark(ctx context.Context, target string) (*controlexecute.ExecutionTree, error) {
+	tree, err := controlexecute.NewExecutionTree(ctx, s.server.workspace, s.server.dbClient, "", target)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.Execute(ctx); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// export exports the results of a schedule run to the schedule output directory
+func (s *scheduler) export(ctx context.Context, schedule *modconfig.Schedule, fileNameRoot string, source export.ExportSourceData) ([]string, error) {
+	outputDir, err := scheduleOutputDir(schedule)
+	if err != nil {
+		return nil, err
+	}
+	return s.exportManager(schedule).DoExportToDir(ctx, fileNameRoot, source, schedule.Export, outputDir)
+}
+
+// exportManager returns the export manager for the schedule target type
+func (s *scheduler) exportManager(schedule *modconfig.Schedule) *export.Manager {
+	if parsedName, err := modconfig.ParseResourceName(schedule.Target); err == nil && parsedName.ItemType == modconfig.BlockTypeBenchmark {
+		return s.checkExportManager
+	}
+	return s.dashboardExportManager
+}
+
+// setRunning marks the schedule as running, returning false if it is already running
+func (s *scheduler) setRunning(name string) bool {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+
+	if s.running[name] {
+		return false
+	}
+	s.running[name] = true
+	return true
+}
+
+func (s *scheduler) clearRunning(name string) {
+	s.runningMutex.Lock()
+	defer s.runningMutex.Unlock()
+
+	delete(s.running, name)
+}
+
+// scheduleOutputDir returns the directory the schedule exports are written to (creating it if missing)
+// this is the snapshot location of the schedule if set, or a directory for the schedule in the schedules directory
+func scheduleOutputDir(schedule *modconfig.Schedule) (string, error) {
+	var outputDir string
+	if schedule.SnapshotLocation != nil {
+		var err error
+		if outputDir, err = filehelpers.Tildefy(*schedule.SnapshotLocation); err != nil {
+			return "", err
+		}
+	} else {
+		outputDir = filepath.Join(filepaths.EnsureSchedulesDir(), schedule.Name)
+	}
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return "", err
+	}
+	return outputDir, nil
+}
+
+// loadScheduleHistory returns the stored runs of the schedule with the given name, oldest first
+func loadScheduleHistory(name string) ([]*ScheduleRun, error) {
+	file, err := os.Open(scheduleHistoryPath(name))
+	if err != nil {
+		// if the schedule has not run yet, there is no history
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var history []*ScheduleRun
+	// ignore EOF (caused by empty file)
+	if err := json.NewDecoder(file).Decode(&history); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return history, nil
+}
+
+// appendScheduleHistory adds the run to the history of its schedule, trimming the history to ScheduleHistorySize
+func appendScheduleHistory(scheduleRun *ScheduleRun) error {
+	history, err := loadScheduleHistory(scheduleRun.Schedule)
+	if err != nil {
+		return err
+	}
+	history = append(history, scheduleRun)
+	if len(history) > constants.ScheduleHistorySize {
+		history = history[len(history)-constants.ScheduleHistorySize:]
+	}
+
+	historyBytes, err := json.Marshal(history)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(scheduleHistoryPath(scheduleRun.Schedule), historyBytes, 0644)
+}
+
+func scheduleHistoryPath(name string) string {
+	return filepath.Join(filepaths.EnsureScheduleHistoryDir(), fmt.Sprintf("%s.json", name))
+}
@@ -0,0 +1,130 @@
+package dashboardserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/turbot/steampipe/pkg/control/controldisplay"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
+	"github.com/turbot/steampipe/pkg/export"
+	"github.com/turbot/steampipe/pkg/filepaths"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+func newTestScheduler(t *testing.T) *scheduler {
+	dashboardExportManager := export.NewManager()
+	if err := dashboardExportManager.Register(&export.SnapshotExporter{}); err != nil {
+		t.Fatal(err)
+	}
+	checkExportManager := export.NewManager()
+	for _, formatter := range []controldisplay.Formatter{&controldisplay.SnapshotFormatter{}, &controldisplay.SarifFormatter{}, &controldisplay.JUnitFormatter{}} {
+		if err := checkExportManager.Register(controldisplay.NewControlExporter(formatter)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return &scheduler{dashboardExportManager: dashboardExportManager, checkExportManager: checkExportManager}
+}
+
+type scheduleExportTest struct {
+	target      string
+	export      []string
+	expectError bool
+}
+
+var testCasesScheduleExport = map[string]scheduleExportTest{
+	"dashboard snapshot": {target: "dashboard.d1", export: []string{"snapshot"}},
+	"dashboard sarif":    {target: "dashboard.d1", export: []string{"sarif"}, expectError: true},
+	"benchmark snapshot": {target: "benchmark.b1", export: []string{"snapshot"}},
+	"benchmark sarif":    {target: "benchmark.b1", export: []string{"sarif", "junit"}},
+}
+
+func TestScheduleExportFormats(t *testing.T) {
+	s := newTestScheduler(t)
+	for name, test := range testCasesScheduleExport {
+		schedule := &modconfig.Schedule{Name: "s1", Target: test.target, Export: test.export}
+		err := s.exportManager(schedule).ValidateExportFormat(schedule.Export)
+		if test.expectError && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected an error", name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+		}
+	}
+}
+
+func TestScheduleExportFileName(t *testing.T) {
+	s := newTestScheduler(t)
+	outputDir := t.TempDir()
+	schedule := &modconfig.Schedule{Name: "s1", Target: "dashboard.d1", Export: []string{"snapshot"}, SnapshotLocation: &outputDir}
+
+	outputs, err := s.export(context.Background(), schedule, "local.dashboard.d1", &dashboardtypes.SteampipeSnapshot{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 1 {
+		t.Fatalf("expected 1 export, got %d", len(outputs))
+	}
+	fileName := filepath.Base(outputs[0])
+	if filepath.Dir(outputs[0]) != outputDir || !strings.HasPrefix(fileName, "local.dashboard.d1.") || filepath.Ext(fileName) != ".sps" {
+		t.Errorf("expected a snapshot named after the target in %s, got %s", outputDir, outputs[0])
+	}
+}
+
+func TestScheduleHistoryAPI(t *testing.T) {
+	filepaths.SteampipeDir = t.TempDir()
+	prevConfig := steampipeconfig.GlobalConfig
+	defer func() { steampipeconfig.GlobalConfig = prevConfig }()
+	steampipeconfig.GlobalConfig = &steampipeconfig.SteampipeConfig{
+		Schedules: map[string]*modconfig.Schedule{
+			"s1": {Name: "s1", Cron: "@daily", Target: "dashboard.d1"},
+			"s2": {Name: "s2", Cron: "@daily", Target: "benchmark.b1"},
+		},
+	}
+	for _, status := range []string{ScheduleRunStatusError, ScheduleRunStatusComplete} {
+		if err := appendScheduleHistory(&ScheduleRun{Schedule: "s1", Status: status}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := newRouter(&Server{}, t.TempDir())
+	get := func(url string, target any) int {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "http://localhost:9194"+url, nil))
+		if w.Code == http.StatusOK {
+			if err := json.Unmarshal(w.Body.Bytes(), target); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return w.Code
+	}
+
+	var schedules []apiSchedule
+	if status := get("/api/schedules", &schedules); status != http.StatusOK {
+		t.Fatalf("expected status 200 listing schedules, got %d", status)
+	}
+	if len(schedules) != 2 || schedules[0].Name != "s1" || schedules[1].Name != "s2" {
+		t.Fatalf("expected schedules s1 and s2, got %v", schedules)
+	}
+	if schedules[0].LastRun == nil || schedules[0].LastRun.Status != ScheduleRunStatusComplete || schedules[1].LastRun != nil {
+		t.Errorf("unexpected last runs: %v, %v", schedules[0].LastRun, schedules[1].LastRun)
+	}
+
+	var runs []*ScheduleRun
+	if status := get("/api/schedules/s1/runs", &runs); status != http.StatusOK {
+		t.Fatalf("expected status 200 getting schedule runs, got %d", status)
+	}
+	if len(runs) != 2 || runs[0].Status != ScheduleRunStatusComplete || runs[1].Status != ScheduleRunStatusError {
+		t.Errorf("expected the runs most recent first, got %v", runs)
+	}
+	if status := get("/api/schedules/unknown/runs", &runs); status != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown schedule, got %d", status)
+	}
+}
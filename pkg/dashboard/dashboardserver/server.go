@@ -2,14 +2,19 @@ package dashboardserver
 
 import (
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"github.com/spf13/viper"
 	"github.com/turbot/go-kit/helpers"
 	typeHelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardevents"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardexecute"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardsnapshot"
 	"github.com/turbot/steampipe/pkg/db/db_common"
 	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/workspace"
 	"gopkg.in/olahol/melody.v1"
@@ -18,6 +23,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 )
 
 type Server struct {
@@ -26,14 +32,30 @@ type Server struct {
 	dashboardClients map[string]*DashboardClientInfo
 	webSocket        *melody.Melody
 	workspace        *workspace.Workspace
+	authenticators   []authenticator
+	tlsConfig        *tls.Config
+	snapshotLibrary  *dashboardsnapshot.Library
+	// map of the session id of runs started by the REST API or a schedule to the channel used to return the run result
+	runs      map[string]chan *runResult
+	runsMutex sync.Mutex
+	// runs the schedules defined in the config - only set when running as a service
+	scheduler *scheduler
 }
 
 func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Workspace) (*Server, error) {
 	initLogSink()
 
+	authenticators, err := newAuthenticators()
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := newTLSConfig()
+	if err != nil {
+		return nil, err
+	}
 	OutputWait(ctx, "Starting Dashboard Server")
 
-	webSocket := melody.New()
+	webSocket := newWebSocket()
 
 	var dashboardClients = make(map[string]*DashboardClientInfo)
 
@@ -45,26 +67,53 @@ func NewServer(ctx context.Context, dbClient db_common.Client, w *workspace.Work
 		dashboardClients: dashboardClients,
 		webSocket:        webSocket,
 		workspace:        w,
+		authenticators:   authenticators,
+		tlsConfig:        tlsConfig,
+		snapshotLibrary:  dashboardsnapshot.DefaultLibrary(),
+		runs:             make(map[string]chan *runResult),
+	}
+
+	// when running as a service, also run the schedules defined in the config
+	if schedules := steampipeconfig.GlobalConfig.Schedules; viper.GetBool(constants.ArgServiceMode) && len(schedules) > 0 {
+		if server.scheduler, err = newScheduler(ctx, server, schedules); err != nil {
+			return nil, err
+		}
 	}
 
 	w.RegisterDashboardEventHandler(ctx, server.HandleDashboardEvent)
-	err := w.SetupWatcher(ctx, dbClient, func(c context.Context, e error) {})
+	w.RegisterDashboardEventHandler(ctx, server.handleRunEvent)
+	err = w.SetupWatcher(ctx, dbClient, func(c context.Context, e error) {})
 	OutputMessage(ctx, "Workspace loaded")
 
 	return server, err
 }
 
+// newWebSocket creates the websocket handler - upgrades are only accepted from the dashboard itself
+func newWebSocket() *melody.Melody {
+	webSocket := melody.New()
+	webSocket.Upgrader.CheckOrigin = websocketOriginCheck(apiAllowedHosts())
+	return webSocket
+}
+
 // Start starts the API server
 // it returns a channel which is signalled when the API server terminates
 func (s *Server) Start(ctx context.Context) chan struct{} {
 	s.initAsync(ctx)
-	return startAPIAsync(ctx, s.webSocket)
+	if s.scheduler != nil {
+		s.scheduler.start(ctx)
+	}
+	return startAPIAsync(ctx, s)
 }
 
 // Shutdown stops the API server
 func (s *Server) Shutdown(ctx context.Context) {
 	log.Println("[TRACE] Server shutdown")
 
+	if s.scheduler != nil {
+		log.Println("[TRACE] stopping scheduler")
+		s.scheduler.stop()
+	}
+
 	if s.webSocket != nil {
 		log.Println("[TRACE] closing websocket")
 		if err := s.webSocket.Close(); err != nil {
@@ -212,7 +261,7 @@ func (s *Server) HandleDashboardEvent(ctx context.Context, event dashboardevents
 			_ = s.webSocket.Broadcast(payload)
 
 			// Emit available dashboards event
-			payload, payloadError = buildAvailableDashboardsPayload(s.workspace.GetResourceMaps())
+			payload, payloadError = buildAvailableDashboardsPayload(s.workspace.GetResourceMaps(), s.snapshotLibrary)
 			if payloadError != nil {
 				return
 			}
@@ -348,7 +397,7 @@ func (s *Server) handleMessageFunc(ctx context.Context) func(session *melody.Ses
 			}
 			_ = session.Write(payload)
 		case "get_available_dashboards":
-			payload, err := buildAvailableDashboardsPayload(s.workspace.GetResourceMaps())
+			payload, err := buildAvailableDashboardsPayload(s.workspace.GetResourceMaps(), s.snapshotLibrary)
 			if err != nil {
 				panic(fmt.Errorf("error building payload for get_available_dashboards: %v", err))
 			}
@@ -360,15 +409,32 @@ func (s *Server) handleMessageFunc(ctx context.Context) func(session *melody.Ses
 			snapshotName := request.Payload.Dashboard.FullName
 			s.setDashboardForSession(sessionId, snapshotName, request.Payload.InputValues)
 			snap, err := dashboardexecute.Executor.LoadSnapshot(ctx, sessionId, snapshotName, s.workspace)
-			// TACTICAL- handle with error message
-			error_helpers.FailOnError(err)
-			// error handling???
+			if err != nil {
+				OutputError(ctx, err)
+				payload, _ := buildExecutionErrorPayload(&dashboardevents.ExecutionError{Error: err, Session: sessionId, Timestamp: time.Now()})
+				s.writePayloadToSession(sessionId, payload)
+				return
+			}
 			payload, err := buildDisplaySnapshotPayload(snap)
 			// TACTICAL- handle with error message
 			error_helpers.FailOnError(err)
 
 			s.writePayloadToSession(sessionId, payload)
 			outputReady(ctx, fmt.Sprintf("Show snapshot complete: %s", snapshotName))
+		case "get_snapshots":
+			s.writeAvailableSnapshots(ctx, session, request.Payload.SnapshotFilter.filter())
+		// NOTE: this is destructive - it relies on the websocket origin check (see newWebSocket) to ensure only the
+		// dashboard itself can send it, as there is no authentication by default
+		case "delete_snapshot":
+			snapshotName := request.Payload.Dashboard.FullName
+			if err := s.snapshotLibrary.Delete(snapshotName); err != nil {
+				OutputError(ctx, err)
+				payload, _ := buildSnapshotErrorPayload(err)
+				_ = session.Write(payload)
+				return
+			}
+			OutputMessage(ctx, fmt.Sprintf("Snapshot deleted: %s", snapshotName))
+			s.writeAvailableSnapshots(ctx, session, nil)
 		case "input_changed":
 			s.setDashboardInputsForSession(sessionId, request.Payload.InputValues)
 			_ = dashboardexecute.Executor.OnInputChanged(ctx, sessionId, request.Payload.InputValues, request.Payload.ChangedInput)
@@ -379,6 +445,22 @@ func (s *Server) handleMessageFunc(ctx context.Context) func(session *melody.Ses
 	}
 }
 
+// writeAvailableSnapshots writes the snapshots in the snapshot library which match the filter to the session
+func (s *Server) writeAvailableSnapshots(ctx context.Context, session *melody.Session, filter *dashboardsnapshot.Filter) {
+	snapshots, err := s.snapshotLibrary.List(filter)
+	var payload []byte
+	if err != nil {
+		OutputError(ctx, err)
+		payload, _ = buildSnapshotErrorPayload(err)
+	} else {
+		payload, err = buildAvailableSnapshotsPayload(snapshots)
+		if err != nil {
+			panic(fmt.Errorf("error building payload for get_snapshots: %v", err))
+		}
+	}
+	_ = session.Write(payload)
+}
+
 func (s *Server) clearSession(ctx context.Context, session *melody.Session) {
 	if strings.ToUpper(os.Getenv("DEBUG")) == "TRUE" {
 		return
@@ -35,6 +35,7 @@ type DashboardServiceState struct {
 	Port          int          `json:"port"`
 	ListenType    string       `json:"listen_type"`
 	Listen        []string     `json:"listen"`
+	TLS           bool         `json:"tls"`
 	StructVersion int64        `json:"struct_version"`
 }
 
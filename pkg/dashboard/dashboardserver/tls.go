@@ -0,0 +1,58 @@
+package dashboardserver
+
+import (
+	"crypto/tls"
+	"fmt"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/db/db_local"
+	"github.com/turbot/steampipe/pkg/filepaths"
+)
+
+// TLSEnabled returns whether the dashboard is served over https
+// this is enabled by setting tls, or by setting the certificate and key to use, in the dashboard options
+func TLSEnabled() bool {
+	return viper.GetBool(constants.ArgDashboardTLS) ||
+		viper.GetString(constants.ArgDashboardTLSCert) != "" ||
+		viper.GetString(constants.ArgDashboardTLSKey) != ""
+}
+
+// URLScheme returns the scheme of the dashboard server URL - https if TLS is enabled
+func URLScheme() string {
+	if TLSEnabled() {
+		return "https"
+	}
+	return "http"
+}
+
+// newTLSConfig loads the certificate used to serve the dashboard over https - returning nil if TLS is not enabled
+// if no certificate and key are set in the dashboard options, a self-signed certificate is generated
+func newTLSConfig() (*tls.Config, error) {
+	if !TLSEnabled() {
+		return nil, nil
+	}
+
+	certPath := viper.GetString(constants.ArgDashboardTLSCert)
+	keyPath := viper.GetString(constants.ArgDashboardTLSKey)
+	switch {
+	case certPath == "" && keyPath == "":
+		if err := db_local.EnsureDashboardCertificate(); err != nil {
+			return nil, sperr.WrapWithMessage(err, "failed to generate the dashboard certificate")
+		}
+		certPath = filepaths.DashboardCertLocation()
+		keyPath = filepaths.DashboardCertKeyLocation()
+	case certPath == "" || keyPath == "":
+		return nil, fmt.Errorf("both tls_cert and tls_key must be set to serve the dashboard using a custom certificate")
+	}
+
+	certificate, err := tls.LoadX509KeyPair(certPath, keyPath)
+	if err != nil {
+		return nil, sperr.WrapWithMessage(err, "failed to load the dashboard certificate")
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{certificate},
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -0,0 +1,153 @@
+package dashboardserver
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/db/db_local"
+	"github.com/turbot/steampipe/pkg/filepaths"
+)
+
+// writeTestCertificate writes a self-signed certificate and key to the directory, returning their paths
+func writeTestCertificate(t *testing.T, dir, commonName string) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificateData := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, certificateData, certificateData, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certificateBytes}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return certPath, keyPath
+}
+
+type tlsConfigTest struct {
+	tls bool
+	// the certificate and key options - 'user' is replaced with the path of a user supplied certificate or key
+	cert string
+	key  string
+	// the common name of the certificate served - empty if TLS is not enabled
+	expected    string
+	expectError bool
+}
+
+var testCasesTLSConfig = map[string]tlsConfigTest{
+	"disabled": {
+		expected: "",
+	},
+	"self-signed": {
+		tls:      true,
+		expected: db_local.CertIssuer,
+	},
+	"user certificate": {
+		cert:     "user",
+		key:      "user",
+		expected: "user.example.com",
+	},
+	"user certificate with tls": {
+		tls:      true,
+		cert:     "user",
+		key:      "user",
+		expected: "user.example.com",
+	},
+	"certificate without key": {
+		cert:        "user",
+		expectError: true,
+	},
+	"key without certificate": {
+		tls:         true,
+		key:         "user",
+		expectError: true,
+	},
+	"missing certificate": {
+		cert:        "missing.pem",
+		key:         "user",
+		expectError: true,
+	},
+}
+
+func TestNewTLSConfig(t *testing.T) {
+	defer func() {
+		viper.Set(constants.ArgDashboardTLS, false)
+		viper.Set(constants.ArgDashboardTLSCert, "")
+		viper.Set(constants.ArgDashboardTLSKey, "")
+	}()
+
+	for name, test := range testCasesTLSConfig {
+		filepaths.SteampipeDir = t.TempDir()
+		certPath, keyPath := writeTestCertificate(t, t.TempDir(), "user.example.com")
+		if test.cert == "user" {
+			test.cert = certPath
+		}
+		if test.key == "user" {
+			test.key = keyPath
+		}
+		viper.Set(constants.ArgDashboardTLS, test.tls)
+		viper.Set(constants.ArgDashboardTLSCert, test.cert)
+		viper.Set(constants.ArgDashboardTLSKey, test.key)
+
+		tlsConfig, err := newTLSConfig()
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+
+		if test.expected == "" {
+			if tlsConfig != nil {
+				t.Errorf("Test: '%s'' FAILED : expected no TLS config", name)
+			}
+			continue
+		}
+		if tlsConfig == nil || len(tlsConfig.Certificates) != 1 {
+			t.Errorf("Test: '%s'' FAILED : expected a TLS config with a certificate", name)
+			continue
+		}
+		if tlsConfig.MinVersion != tls.VersionTLS12 {
+			t.Errorf("Test: '%s'' FAILED : expected a minimum version of TLS 1.2", name)
+		}
+		certificate, err := x509.ParseCertificate(tlsConfig.Certificates[0].Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if certificate.Subject.CommonName != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected the certificate for %s, got %s", name, test.expected, certificate.Subject.CommonName)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package dashboardserver
 import (
 	"fmt"
 	"github.com/turbot/steampipe/pkg/control/controlstatus"
+	"github.com/turbot/steampipe/pkg/dashboard/dashboardsnapshot"
 	"github.com/turbot/steampipe/pkg/dashboard/dashboardtypes"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"gopkg.in/olahol/melody.v1"
@@ -112,10 +113,33 @@ type ClientRequestDashboardPayload struct {
 	FullName string `json:"full_name"`
 }
 
+// ClientRequestSnapshotFilterPayload filters the snapshots returned by get_snapshots
+type ClientRequestSnapshotFilterPayload struct {
+	Search string            `json:"search"`
+	Tags   map[string]string `json:"tags"`
+	From   *time.Time        `json:"from"`
+	To     *time.Time        `json:"to"`
+}
+
+func (p ClientRequestSnapshotFilterPayload) filter() *dashboardsnapshot.Filter {
+	res := &dashboardsnapshot.Filter{
+		Search: p.Search,
+		Tags:   p.Tags,
+	}
+	if p.From != nil {
+		res.From = *p.From
+	}
+	if p.To != nil {
+		res.To = *p.To
+	}
+	return res
+}
+
 type ClientRequestPayload struct {
-	Dashboard    ClientRequestDashboardPayload `json:"dashboard"`
-	InputValues  map[string]interface{}        `json:"input_values"`
-	ChangedInput string                        `json:"changed_input"`
+	Dashboard      ClientRequestDashboardPayload      `json:"dashboard"`
+	InputValues    map[string]interface{}             `json:"input_values"`
+	ChangedInput   string                             `json:"changed_input"`
+	SnapshotFilter ClientRequestSnapshotFilterPayload `json:"snapshot_filter"`
 }
 
 type ClientRequest struct {
@@ -149,6 +173,11 @@ type AvailableDashboardsPayload struct {
 	Snapshots  map[string]string                `json:"snapshots"`
 }
 
+type AvailableSnapshotsPayload struct {
+	Action    string                            `json:"action"`
+	Snapshots []*dashboardsnapshot.SnapshotInfo `json:"snapshots"`
+}
+
 type ModDashboardMetadata struct {
 	Title     string `json:"title,omitempty"`
 	FullName  string `json:"full_name"`
@@ -0,0 +1,419 @@
+package dashboardsnapshot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	filehelpers "github.com/turbot/go-kit/files"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/filepaths"
+	"github.com/turbot/steampipe/pkg/steampipeconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// the library index may be read and written by both the dashboard server and the dashboard executor
+var indexMutex sync.Mutex
+
+// SnapshotInfo is the index entry of a snapshot in the library
+type SnapshotInfo struct {
+	// the name used to open the snapshot, i.e. snapshot.<file name without extension>
+	Name     string `json:"name"`
+	FileName string `json:"file_name"`
+	Title    string `json:"title,omitempty"`
+	// set if the title was given when the snapshot was added, rather than read from the snapshot
+	TitleAdded bool `json:"title_added,omitempty"`
+	// the name of the dashboard or benchmark the snapshot was taken of
+	Dashboard string            `json:"dashboard,omitempty"`
+	Tags      map[string]string `json:"tags,omitempty"`
+	StartTime time.Time         `json:"start_time"`
+	Size      int64             `json:"size"`
+	// used to detect whether the snapshot file has changed since it was indexed
+	ModTime time.Time `json:"mod_time"`
+}
+
+// Filter selects the snapshots returned by Library.List - unset fields match all snapshots
+type Filter struct {
+	// matched (case insensitive) against the name, title and dashboard of the snapshot
+	Search string
+	// the snapshot must have all of these tags
+	Tags map[string]string
+	// the snapshot must have been taken within this time range
+	From time.Time
+	To   time.Time
+}
+
+// NewFilter returns a filter for the given search, tags and time range
+// from and to may be RFC 3339 timestamps or dates (yyyy-mm-dd) - a to date without a time includes the whole day
+func NewFilter(search string, tags map[string]string, from, to string) (*Filter, error) {
+	res := &Filter{
+		Search: search,
+		Tags:   tags,
+	}
+	var err error
+	if res.From, err = parseFilterTime(from); err != nil {
+		return nil, err
+	}
+	if res.To, err = parseFilterTime(to); err != nil {
+		return nil, err
+	}
+	if to != "" && !strings.Contains(to, "T") {
+		res.To = res.To.Add(24*time.Hour - time.Nanosecond)
+	}
+	return res, nil
+}
+
+func parseFilterTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time '%s' - must be an RFC 3339 timestamp or a date (yyyy-mm-dd)", value)
+}
+
+func (f *Filter) matches(info *SnapshotInfo) bool {
+	if f == nil {
+		return true
+	}
+	if search := strings.ToLower(strings.TrimSpace(f.Search)); search != "" &&
+		!strings.Contains(strings.ToLower(info.Name), search) &&
+		!strings.Contains(strings.ToLower(info.Title), search) &&
+		!strings.Contains(strings.ToLower(info.Dashboard), search) {
+		return false
+	}
+	for key, value := range f.Tags {
+		if tagValue, ok := info.Tags[key]; !ok || tagValue != value {
+			return false
+		}
+	}
+	if !f.From.IsZero() && info.StartTime.Before(f.From) {
+		return false
+	}
+	if !f.To.IsZero() && info.StartTime.After(f.To) {
+		return false
+	}
+	return true
+}
+
+// Library is a local directory of saved snapshots, with an index of their metadata
+//
+// snapshots may be added to the directory by any means (e.g. --snapshot or --export) - the index is brought
+// up to date with the directory contents whenever it is read
+//
+// the index is stored in the steampipe install dir, rather than the library directory, so that saving a snapshot
+// to a user directory does not add any other files to it
+type Library struct {
+	dir string
+}
+
+func NewLibrary(dir string) *Library {
+	return &Library{dir: dir}
+}
+
+// DefaultLibrary returns the library in the snapshot location, if this is a local directory,
+// otherwise the library in the snapshots directory
+// if the snapshot location is not a valid directory, a warning is logged and the snapshots directory is used
+func DefaultLibrary() *Library {
+	if snapshotLocation := viper.GetString(constants.ArgSnapshotLocation); snapshotLocation != "" && !steampipeconfig.IsCloudWorkspaceIdentifier(snapshotLocation) {
+		dir, err := filehelpers.Tildefy(snapshotLocation)
+		if err == nil && filehelpers.DirectoryExists(dir) {
+			return NewLibrary(dir)
+		}
+		log.Printf("[WARN] snapshot location %s does not exist - using the snapshots directory for the snapshot library", snapshotLocation)
+	}
+	return NewLibrary(filepaths.EnsureSnapshotsDir())
+}
+
+func (l *Library) Dir() string {
+	return l.dir
+}
+
+// List returns the snapshots which match the filter, most recent first
+func (l *Library) List(filter *Filter) ([]*SnapshotInfo, error) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	index, err := l.syncIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []*SnapshotInfo
+	for _, info := range index {
+		if filter.matches(info) {
+			res = append(res, info)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].StartTime.After(res[j].StartTime)
+	})
+	return res, nil
+}
+
+// Get returns the snapshot with the given name
+func (l *Library) Get(name string) (*SnapshotInfo, error) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	index, err := l.syncIndex()
+	if err != nil {
+		return nil, err
+	}
+	return l.find(index, name)
+}
+
+// Path returns the path of the snapshot file
+func (l *Library) Path(info *SnapshotInfo) string {
+	return filepath.Join(l.dir, info.FileName)
+}
+
+// Load returns the contents of the snapshot with the given name
+func (l *Library) Load(name string) (map[string]any, error) {
+	info, err := l.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	return ReadSnapshotFile(l.Path(info))
+}
+
+// Add records the title and tags of a snapshot file which has been written to the library directory
+func (l *Library) Add(fileName, title string, tags map[string]string) error {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	// only index the added file - the rest of the directory is synced when the library is next read
+	index, err := l.loadIndex()
+	if err != nil {
+		return err
+	}
+	fileName = filepath.Base(fileName)
+	fileInfo, err := os.Stat(filepath.Join(l.dir, fileName))
+	if err != nil {
+		return fmt.Errorf("snapshot %s is not in the snapshot library %s", fileName, l.dir)
+	}
+	info, err := readSnapshotInfo(filepath.Join(l.dir, fileName), fileInfo)
+	if err != nil {
+		return err
+	}
+	if title != "" {
+		info.Title = title
+		info.TitleAdded = true
+	}
+	if len(tags) > 0 {
+		info.Tags = tags
+	}
+	index[fileName] = info
+	return l.saveIndex(index)
+}
+
+// Delete deletes the snapshot with the given name from the library
+func (l *Library) Delete(name string) error {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	index, err := l.syncIndex()
+	if err != nil {
+		return err
+	}
+	info, err := l.find(index, name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(l.Path(info)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	delete(index, info.FileName)
+	return l.saveIndex(index)
+}
+
+func (l *Library) find(index map[string]*SnapshotInfo, name string) (*SnapshotInfo, error) {
+	for _, info := range index {
+		if info.Name == name {
+			return info, nil
+		}
+	}
+	return nil, fmt.Errorf("snapshot %s not found in %s", name, l.dir)
+}
+
+// syncIndex loads the index, keyed by file name, and updates it with any snapshot files which have been added,
+// changed or removed since it was saved
+// NOTE: indexMutex must be held by the caller
+func (l *Library) syncIndex() (map[string]*SnapshotInfo, error) {
+	index, err := l.loadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(l.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := false
+	snapshotFiles := make(map[string]struct{})
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != constants.SnapshotExtension {
+			continue
+		}
+		fileInfo, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		snapshotFiles[entry.Name()] = struct{}{}
+
+		existing, ok := index[entry.Name()]
+		if ok && existing.Size == fileInfo.Size() && existing.ModTime.Equal(fileInfo.ModTime()) {
+			continue
+		}
+		info, err := readSnapshotInfo(filepath.Join(l.dir, entry.Name()), fileInfo)
+		if err != nil {
+			log.Printf("[WARN] failed to add %s to the snapshot library: %s", entry.Name(), err.Error())
+			continue
+		}
+		// keep the title and tags given when the snapshot was added
+		if ok {
+			if existing.TitleAdded {
+				info.Title = existing.Title
+				info.TitleAdded = true
+			}
+			info.Tags = existing.Tags
+		}
+		index[entry.Name()] = info
+		changed = true
+	}
+
+	// remove any snapshots whose files have been deleted
+	for fileName := range index {
+		if _, ok := snapshotFiles[fileName]; !ok {
+			delete(index, fileName)
+			changed = true
+		}
+	}
+
+	if changed {
+		if err := l.saveIndex(index); err != nil {
+			// the index is rebuilt next time, so this is not fatal
+			log.Printf("[WARN] failed to save the snapshot library index: %s", err.Error())
+		}
+	}
+	return index, nil
+}
+
+// indexPath returns the path of the library index - this is keyed by the absolute path of the library directory
+func (l *Library) indexPath() string {
+	dir, err := filepath.Abs(l.dir)
+	if err != nil {
+		dir = l.dir
+	}
+	hash := sha256.Sum256([]byte(dir))
+	return filepath.Join(filepaths.EnsureSnapshotIndexDir(), hex.EncodeToString(hash[:])+".json")
+}
+
+func (l *Library) loadIndex() (map[string]*SnapshotInfo, error) {
+	index := make(map[string]*SnapshotInfo)
+
+	indexBytes, err := os.ReadFile(l.indexPath())
+	if err != nil {
+		// if the library has not been indexed yet, start with an empty index
+		if os.IsNotExist(err) {
+			return index, nil
+		}
+		return nil, err
+	}
+
+	var entries []*SnapshotInfo
+	if err := json.Unmarshal(indexBytes, &entries); err != nil {
+		// the index will be rebuilt from the snapshot files
+		log.Printf("[WARN] ignoring invalid snapshot library index: %s", err.Error())
+		return index, nil
+	}
+	for _, info := range entries {
+		index[info.FileName] = info
+	}
+	return index, nil
+}
+
+func (l *Library) saveIndex(index map[string]*SnapshotInfo) error {
+	entries := make([]*SnapshotInfo, 0, len(index))
+	for _, info := range index {
+		entries = append(entries, info)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].FileName < entries[j].FileName
+	})
+
+	indexBytes, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(l.indexPath(), indexBytes, 0644)
+}
+
+// snapshotHeader is the subset of the snapshot file used to build its index entry
+type snapshotHeader struct {
+	StartTime time.Time `json:"start_time"`
+	Layout    *struct {
+		Name string `json:"name"`
+	} `json:"layout"`
+	Panels map[string]struct {
+		Title string `json:"title"`
+	} `json:"panels"`
+}
+
+func readSnapshotInfo(path string, fileInfo os.FileInfo) (*SnapshotInfo, error) {
+	snapshotBytes, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var header snapshotHeader
+	if err := json.Unmarshal(snapshotBytes, &header); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %s", err.Error())
+	}
+
+	info := &SnapshotInfo{
+		Name:      fmt.Sprintf("snapshot.%s", utils.FilenameNoExtension(path)),
+		FileName:  fileInfo.Name(),
+		StartTime: header.StartTime,
+		Size:      fileInfo.Size(),
+		ModTime:   fileInfo.ModTime(),
+	}
+	// the root of the layout is the dashboard or benchmark the snapshot was taken of
+	if header.Layout != nil {
+		info.Dashboard = header.Layout.Name
+		info.Title = header.Panels[header.Layout.Name].Title
+	}
+	return info, nil
+}
+
+// ReadSnapshotFile reads a snapshot file as an interface map
+// we cannot deserialize into a SteampipeSnapshot struct
+// (without custom deserialisation code) as the Panels property is an interface
+func ReadSnapshotFile(path string) (map[string]any, error) {
+	if !filehelpers.FileExists(path) {
+		return nil, fmt.Errorf("snapshot %s does not exist", path)
+	}
+
+	snapshotContent, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	snap := map[string]any{}
+	if err := json.Unmarshal(snapshotContent, &snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
@@ -0,0 +1,330 @@
+package dashboardsnapshot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/filepaths"
+)
+
+type filterTest struct {
+	search   string
+	tags     map[string]string
+	from     string
+	to       string
+	expected []string
+	// expect NewFilter to fail
+	expectError bool
+}
+
+// the start times of these are 2024-03-01 09:00, 2024-03-02 18:30 and 2024-03-05 00:00 UTC
+var testFilterSnapshots = []*SnapshotInfo{
+	{
+		Name:      "snapshot.aws_report",
+		Title:     "AWS Report",
+		Dashboard: "aws_insights.dashboard.aws_report",
+		Tags:      map[string]string{"env": "prod", "team": "cloud"},
+		StartTime: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	},
+	{
+		Name:      "snapshot.cis_v150",
+		Title:     "CIS v1.5.0",
+		Dashboard: "aws_compliance.benchmark.cis_v150",
+		Tags:      map[string]string{"env": "dev"},
+		StartTime: time.Date(2024, 3, 2, 18, 30, 0, 0, time.UTC),
+	},
+	{
+		Name:      "snapshot.untitled",
+		StartTime: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+var testCasesFilter = map[string]filterTest{
+	"no filter": {
+		expected: []string{"snapshot.aws_report", "snapshot.cis_v150", "snapshot.untitled"},
+	},
+	"search name": {
+		search:   "untitled",
+		expected: []string{"snapshot.untitled"},
+	},
+	"search title case insensitive": {
+		search:   "aws REPORT",
+		expected: []string{"snapshot.aws_report"},
+	},
+	"search dashboard": {
+		search:   "aws_compliance",
+		expected: []string{"snapshot.cis_v150"},
+	},
+	"tag": {
+		tags:     map[string]string{"env": "prod"},
+		expected: []string{"snapshot.aws_report"},
+	},
+	"all tags must match": {
+		tags:     map[string]string{"env": "prod", "team": "security"},
+		expected: nil,
+	},
+	"missing tag": {
+		tags:     map[string]string{"team": "cloud"},
+		expected: []string{"snapshot.aws_report"},
+	},
+	"from date": {
+		from:     "2024-03-02",
+		expected: []string{"snapshot.cis_v150", "snapshot.untitled"},
+	},
+	"from timestamp": {
+		from:     "2024-03-02T19:00:00Z",
+		expected: []string{"snapshot.untitled"},
+	},
+	"to date includes the whole day": {
+		to:       "2024-03-02",
+		expected: []string{"snapshot.aws_report", "snapshot.cis_v150"},
+	},
+	"to timestamp": {
+		to:       "2024-03-02T18:00:00Z",
+		expected: []string{"snapshot.aws_report"},
+	},
+	"to timestamp with offset": {
+		to:       "2024-03-02T20:00:00+01:00",
+		expected: []string{"snapshot.aws_report", "snapshot.cis_v150"},
+	},
+	"date range": {
+		from:     "2024-03-02",
+		to:       "2024-03-02",
+		expected: []string{"snapshot.cis_v150"},
+	},
+	"search and date range": {
+		search:   "aws",
+		from:     "2024-03-01",
+		to:       "2024-03-01",
+		expected: []string{"snapshot.aws_report"},
+	},
+	"invalid from": {
+		from:        "yesterday",
+		expectError: true,
+	},
+	"invalid to": {
+		to:          "2024/03/02",
+		expectError: true,
+	},
+}
+
+func TestFilter(t *testing.T) {
+	for name, test := range testCasesFilter {
+		filter, err := NewFilter(test.search, test.tags, test.from, test.to)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+		var actual []string
+		for _, info := range testFilterSnapshots {
+			if filter.matches(info) {
+				actual = append(actual, info.Name)
+			}
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
+
+// writeTestSnapshot writes a snapshot of the given dashboard to the library directory
+func writeTestSnapshot(t *testing.T, dir, fileName, dashboard string, modTime time.Time) {
+	content := fmt.Sprintf(`{"start_time":"2024-03-01T09:00:00Z","layout":{"name":%q},"panels":{%q:{"title":"Title of %s"}}}`, dashboard, dashboard, dashboard)
+	path := filepath.Join(dir, fileName)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// set the mod time explicitly, as a rewrite may not change it on file systems with a coarse timestamp resolution
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestLibrary(t *testing.T) *Library {
+	filepaths.SteampipeDir = t.TempDir()
+	return NewLibrary(t.TempDir())
+}
+
+type syncIndexTest struct {
+	// update the library directory before the index is synced
+	update   func(t *testing.T, dir string)
+	expected map[string]string
+}
+
+var testModTime = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+// each test is run against a library containing a.sps (of dashboard.a) and b.sps (of dashboard.b),
+// which has been indexed, and a.sps has been added with a title and tags
+var testCasesSyncIndex = map[string]syncIndexTest{
+	"unchanged": {
+		update: func(t *testing.T, dir string) {},
+		expected: map[string]string{
+			"a.sps": "snapshot.a dashboard.a Added title map[env:prod]",
+			"b.sps": "snapshot.b dashboard.b Title of dashboard.b map[]",
+		},
+	},
+	"add": {
+		update: func(t *testing.T, dir string) {
+			writeTestSnapshot(t, dir, "c.sps", "dashboard.c", testModTime)
+		},
+		expected: map[string]string{
+			"a.sps": "snapshot.a dashboard.a Added title map[env:prod]",
+			"b.sps": "snapshot.b dashboard.b Title of dashboard.b map[]",
+			"c.sps": "snapshot.c dashboard.c Title of dashboard.c map[]",
+		},
+	},
+	"change keeps added title and tags": {
+		update: func(t *testing.T, dir string) {
+			writeTestSnapshot(t, dir, "a.sps", "dashboard.changed", testModTime.Add(time.Hour))
+		},
+		expected: map[string]string{
+			"a.sps": "snapshot.a dashboard.changed Added title map[env:prod]",
+			"b.sps": "snapshot.b dashboard.b Title of dashboard.b map[]",
+		},
+	},
+	"change": {
+		update: func(t *testing.T, dir string) {
+			writeTestSnapshot(t, dir, "b.sps", "dashboard.changed", testModTime.Add(time.Hour))
+		},
+		expected: map[string]string{
+			"a.sps": "snapshot.a dashboard.a Added title map[env:prod]",
+			"b.sps": "snapshot.b dashboard.changed Title of dashboard.changed map[]",
+		},
+	},
+	"remove": {
+		update: func(t *testing.T, dir string) {
+			if err := os.Remove(filepath.Join(dir, "b.sps")); err != nil {
+				t.Fatal(err)
+			}
+		},
+		expected: map[string]string{
+			"a.sps": "snapshot.a dashboard.a Added title map[env:prod]",
+		},
+	},
+	"ignore other files": {
+		update: func(t *testing.T, dir string) {
+			if err := os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("notes"), 0644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "invalid.sps"), []byte("not json"), 0644); err != nil {
+				t.Fatal(err)
+			}
+		},
+		expected: map[string]string{
+			"a.sps": "snapshot.a dashboard.a Added title map[env:prod]",
+			"b.sps": "snapshot.b dashboard.b Title of dashboard.b map[]",
+		},
+	},
+}
+
+func TestSyncIndex(t *testing.T) {
+	for name, test := range testCasesSyncIndex {
+		library := newTestLibrary(t)
+		writeTestSnapshot(t, library.dir, "a.sps", "dashboard.a", testModTime)
+		writeTestSnapshot(t, library.dir, "b.sps", "dashboard.b", testModTime)
+		if err := library.Add("a.sps", "Added title", map[string]string{"env": "prod"}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := library.syncIndex(); err != nil {
+			t.Fatal(err)
+		}
+
+		test.update(t, library.dir)
+		if _, err := library.syncIndex(); err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+
+		// check the saved index, rather than the index returned by syncIndex
+		index, err := library.loadIndex()
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual := make(map[string]string)
+		for fileName, info := range index {
+			actual[fileName] = fmt.Sprintf("%s %s %s %v", info.Name, info.Dashboard, info.Title, info.Tags)
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
+
+func TestIndexNotInLibraryDir(t *testing.T) {
+	library := newTestLibrary(t)
+	writeTestSnapshot(t, library.dir, "a.sps", "dashboard.a", testModTime)
+	if err := library.Add("a.sps", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := os.ReadDir(library.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("expected only the snapshot in the library directory, got %d files", len(entries))
+	}
+}
+
+type deleteTest struct {
+	name        string
+	expected    []string
+	expectError bool
+}
+
+var testCasesDelete = map[string]deleteTest{
+	"delete": {
+		name:     "snapshot.a",
+		expected: []string{"snapshot.b"},
+	},
+	"not found": {
+		name:        "snapshot.c",
+		expected:    []string{"snapshot.a", "snapshot.b"},
+		expectError: true,
+	},
+	"name without prefix": {
+		name:        "a",
+		expected:    []string{"snapshot.a", "snapshot.b"},
+		expectError: true,
+	},
+}
+
+func TestDelete(t *testing.T) {
+	for name, test := range testCasesDelete {
+		library := newTestLibrary(t)
+		writeTestSnapshot(t, library.dir, "a.sps", "dashboard.a", testModTime)
+		writeTestSnapshot(t, library.dir, "b.sps", "dashboard.b", testModTime.Add(time.Hour))
+
+		err := library.Delete(test.name)
+		if test.expectError && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected an error", name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+		}
+
+		var actual []string
+		entries, err := os.ReadDir(library.dir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, entry := range entries {
+			actual = append(actual, "snapshot."+entry.Name()[:len(entry.Name())-len(filepath.Ext(entry.Name()))])
+		}
+		if fmt.Sprint(actual) != fmt.Sprint(test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected files %v, got %v", name, test.expected, actual)
+		}
+		if _, err := library.Get("snapshot.a"); (err == nil) != (test.name != "snapshot.a") {
+			t.Errorf("Test: '%s'' FAILED : snapshot.a index entry not as expected after delete", name)
+		}
+	}
+}
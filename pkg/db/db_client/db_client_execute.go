@@ -190,6 +190,12 @@ func (c *DbClient) getQueryTiming(ctx context.Context, startTime time.Time, sess
 		resultChannel <- timingResult
 	}()
 
+	// the scan metadata is read in a transaction of its own - if the query was executed in an open transaction
+	// (e.g. using --single-transaction) this would commit it, so just return the duration
+	if session.Connection.Conn().PgConn().TxStatus() != 'I' {
+		return
+	}
+
 	// load the timing summary
 	summary, err := c.loadTimingSummary(ctx, session)
 	if err != nil {
@@ -237,29 +243,7 @@ connection_count from %s.%s `, constants.InternalSchema, constants.ForeignTableS
 }
 
 func (c *DbClient) loadTimingMetadata(ctx context.Context, session *db_common.DatabaseSession) ([]*queryresult.ScanMetadataRow, error) {
-	var scans []*queryresult.ScanMetadataRow
-
-	err := db_common.ExecuteSystemClientCall(ctx, session.Connection.Conn(), func(ctx context.Context, tx pgx.Tx) error {
-		query := fmt.Sprintf(`
-select connection,
-"table",
-cache_hit, 
-rows_fetched, 
-hydrate_calls, 
-start_time,
-duration_ms,
-columns,
-"limit",
-quals from %s.%s order by duration_ms desc`, constants.InternalSchema, constants.ForeignTableScanMetadata)
-		rows, err := tx.Query(ctx, query)
-		if err != nil {
-			return err
-		}
-
-		scans, err = pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[queryresult.ScanMetadataRow])
-		return err
-	})
-	return scans, err
+	return db_common.LoadScanMetadata(ctx, session)
 }
 
 // run query in a goroutine, so we can check for cancellation
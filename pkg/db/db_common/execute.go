@@ -23,3 +23,21 @@ func ExecuteQuery(ctx context.Context, client Client, queryString string, args .
 	}()
 	return resultsStreamer, nil
 }
+
+// ExecuteQueryInSession executes a single query using the given session
+// the caller is responsible for closing the session once the results have been read
+func ExecuteQueryInSession(ctx context.Context, client Client, session *DatabaseSession, queryString string, args ...any) (*queryresult.ResultStreamer, error) {
+	utils.LogTime("db.ExecuteQueryInSession start")
+	defer utils.LogTime("db.ExecuteQueryInSession end")
+
+	resultsStreamer := queryresult.NewResultStreamer()
+	result, err := client.ExecuteInSession(ctx, session, nil, queryString, args...)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		resultsStreamer.StreamResult(result)
+		resultsStreamer.Close()
+	}()
+	return resultsStreamer, nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/viper"
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/error_helpers"
 )
@@ -42,9 +43,9 @@ func (r *InitResult) DisplayMessages() {
 			error_helpers.ShowWarning(w)
 		}
 	}
-	// do not display message in json or csv output mode
-	output := viper.Get(constants.ArgOutput)
-	if output == constants.OutputFormatJSON || output == constants.OutputFormatCSV {
+	// do not display message in machine readable output modes
+	output := viper.GetString(constants.ArgOutput)
+	if helpers.StringSliceContains(constants.MachineReadableOutputFormats, output) {
 		return
 	}
 	for _, w := range r.Warnings {
@@ -0,0 +1,45 @@
+package db_common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// KeyColumnConfig is the get or list key column config of a plugin column, as stored in the plugin column table
+type KeyColumnConfig struct {
+	Operators  []string `json:"operators,omitempty"`
+	Require    string   `json:"require,omitempty"`
+	CacheMatch string   `json:"cache_match,omitempty"`
+}
+
+// PluginColumn is a row of the plugin column table
+type PluginColumn struct {
+	// the fields of this struct need to be public since these are populated by pgx using RowsToStruct
+	Name        string           `db:"name"`
+	ListConfig  *KeyColumnConfig `db:"list_config"`
+	GetConfig   *KeyColumnConfig `db:"get_config"`
+	HydrateName *string          `db:"hydrate_name"`
+}
+
+// LoadPluginColumns loads the columns of the given plugin table from the plugin column table, keyed by column name
+func LoadPluginColumns(ctx context.Context, conn *pgx.Conn, plugin, table string) (map[string]*PluginColumn, error) {
+	query := fmt.Sprintf(`select name, list_config, get_config, hydrate_name from %s.%s where plugin = $1 and table_name = $2`,
+		constants.InternalSchema, constants.PluginColumnTable)
+	rows, err := conn.Query(ctx, query, plugin, table)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[PluginColumn])
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]*PluginColumn, len(columns))
+	for _, column := range columns {
+		res[column.Name] = column
+	}
+	return res, nil
+}
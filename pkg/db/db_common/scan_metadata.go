@@ -0,0 +1,37 @@
+package db_common
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// LoadScanMetadata loads the scan metadata of the most recent query executed in the session
+func LoadScanMetadata(ctx context.Context, session *DatabaseSession) ([]*queryresult.ScanMetadataRow, error) {
+	var scans []*queryresult.ScanMetadataRow
+
+	err := ExecuteSystemClientCall(ctx, session.Connection.Conn(), func(ctx context.Context, tx pgx.Tx) error {
+		query := fmt.Sprintf(`
+select connection,
+"table",
+cache_hit, 
+rows_fetched, 
+hydrate_calls, 
+start_time,
+duration_ms,
+columns,
+"limit",
+quals from %s.%s order by duration_ms desc`, constants.InternalSchema, constants.ForeignTableScanMetadata)
+		rows, err := tx.Query(ctx, query)
+		if err != nil {
+			return err
+		}
+
+		scans, err = pgx.CollectRows(rows, pgx.RowToAddrOfStructByName[queryresult.ScanMetadataRow])
+		return err
+	})
+	return scans, err
+}
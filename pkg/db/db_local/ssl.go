@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"net"
 	"os"
 	"strconv"
 	"strings"
@@ -257,6 +258,65 @@ func generateServerCertificate(caCertificateData *x509.Certificate, caPrivateKey
 	return nil
 }
 
+// EnsureDashboardCertificate ensures there is a self-signed certificate and key which can be used to serve the
+// dashboard over https, generating them if they do not exist or the certificate is expiring
+func EnsureDashboardCertificate() error {
+	if filehelpers.FileExists(filepaths.DashboardCertLocation()) && filehelpers.FileExists(filepaths.DashboardCertKeyLocation()) {
+		certificate, err := sslio.ParseCertificateInLocation(filepaths.DashboardCertLocation())
+		if err == nil && !isCerticateExpiring(certificate) {
+			return nil
+		}
+	}
+	return generateDashboardCertificate()
+}
+
+// generateDashboardCertificate creates a self-signed certificate for the dashboard server,
+// valid for localhost and the host name of this machine
+func generateDashboardCertificate() error {
+	utils.LogTime("db_local.generateDashboardCertificate start")
+	defer utils.LogTime("db_local.generateDashboardCertificate end")
+
+	now := time.Now()
+
+	dnsNames := []string{"localhost"}
+	if hostname, err := os.Hostname(); err == nil && hostname != "localhost" {
+		dnsNames = append(dnsNames, hostname)
+	}
+	certificateData := &x509.Certificate{
+		SerialNumber:          getSerialNumber(now),
+		Subject:               pkix.Name{CommonName: CertIssuer},
+		NotBefore:             now,
+		NotAfter:              now.Add(ServerCertValidityPeriod),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		DNSNames:              dnsNames,
+		IPAddresses:           []net.IP{net.IPv4(127, 0, 0, 1), net.IPv6loopback},
+	}
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return err
+	}
+
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, certificateData, certificateData, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		log.Println("[INFO] Failed to create dashboard certificate")
+		return err
+	}
+
+	if err := sslio.WriteCertificate(filepaths.DashboardCertLocation(), certificateBytes); err != nil {
+		log.Println("[INFO] Failed to save dashboard certificate")
+		return err
+	}
+	if err := sslio.WritePrivateKey(filepaths.DashboardCertKeyLocation(), privateKey); err != nil {
+		log.Println("[INFO] Failed to save dashboard private key")
+		return err
+	}
+
+	return nil
+}
+
 // getSerialNumber generates a serial number for the certificate based on the passed in time in the format YYYYMMDD
 func getSerialNumber(t time.Time) *big.Int {
 	serialNumber, _ := strconv.ParseInt(
@@ -0,0 +1,135 @@
+package db_local
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/db/sslio"
+	"github.com/turbot/steampipe/pkg/filepaths"
+)
+
+// writeTestDashboardCertificate writes a dashboard certificate valid between the given times
+func writeTestDashboardCertificate(t *testing.T, notBefore, notAfter time.Time) {
+	certificateData := &x509.Certificate{
+		SerialNumber: getSerialNumber(notBefore),
+		Subject:      pkix.Name{CommonName: CertIssuer},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificateBytes, err := x509.CreateCertificate(rand.Reader, certificateData, certificateData, &privateKey.PublicKey, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sslio.WriteCertificate(filepaths.DashboardCertLocation(), certificateBytes); err != nil {
+		t.Fatal(err)
+	}
+	if err := sslio.WritePrivateKey(filepaths.DashboardCertKeyLocation(), privateKey); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type dashboardCertificateTest struct {
+	// set up the install dir before the certificate is ensured - nil if there is no existing certificate
+	setup       func(t *testing.T)
+	regenerated bool
+}
+
+var testCasesDashboardCertificate = map[string]dashboardCertificateTest{
+	"no certificate": {
+		regenerated: true,
+	},
+	"valid certificate is reused": {
+		setup: func(t *testing.T) {
+			writeTestDashboardCertificate(t, time.Now().Add(-24*time.Hour), time.Now().Add(ServerCertValidityPeriod))
+		},
+		regenerated: false,
+	},
+	"expiring certificate": {
+		// 3/4 of the lifetime of the certificate has elapsed
+		setup: func(t *testing.T) {
+			writeTestDashboardCertificate(t, time.Now().Add(-80*24*time.Hour), time.Now().Add(20*24*time.Hour))
+		},
+		regenerated: true,
+	},
+	"expired certificate": {
+		setup: func(t *testing.T) {
+			writeTestDashboardCertificate(t, time.Now().Add(-48*time.Hour), time.Now().Add(-24*time.Hour))
+		},
+		regenerated: true,
+	},
+	"missing key": {
+		setup: func(t *testing.T) {
+			writeTestDashboardCertificate(t, time.Now().Add(-24*time.Hour), time.Now().Add(ServerCertValidityPeriod))
+			if err := os.Remove(filepaths.DashboardCertKeyLocation()); err != nil {
+				t.Fatal(err)
+			}
+		},
+		regenerated: true,
+	},
+	"invalid certificate": {
+		setup: func(t *testing.T) {
+			writeTestDashboardCertificate(t, time.Now().Add(-24*time.Hour), time.Now().Add(ServerCertValidityPeriod))
+			if err := os.WriteFile(filepaths.DashboardCertLocation(), []byte("not a certificate"), 0600); err != nil {
+				t.Fatal(err)
+			}
+		},
+		regenerated: true,
+	},
+}
+
+func TestEnsureDashboardCertificate(t *testing.T) {
+	for name, test := range testCasesDashboardCertificate {
+		filepaths.SteampipeDir = t.TempDir()
+		var existing []byte
+		if test.setup != nil {
+			test.setup(t)
+			existing, _ = os.ReadFile(filepaths.DashboardCertLocation())
+		}
+
+		if err := EnsureDashboardCertificate(); err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+
+		certificateBytes, err := os.ReadFile(filepaths.DashboardCertLocation())
+		if err != nil {
+			t.Fatal(err)
+		}
+		// the key is generated randomly, so a regenerated certificate is always different
+		if regenerated := !bytes.Equal(certificateBytes, existing); regenerated != test.regenerated {
+			t.Errorf("Test: '%s'' FAILED : expected regenerated %v, got %v", name, test.regenerated, regenerated)
+		}
+
+		// the certificate and key must be a valid pair, which is not expiring
+		if _, err := tls.LoadX509KeyPair(filepaths.DashboardCertLocation(), filepaths.DashboardCertKeyLocation()); err != nil {
+			t.Errorf("Test: '%s'' FAILED : invalid certificate and key: %s", name, err.Error())
+			continue
+		}
+		certificate, err := sslio.ParseCertificateInLocation(filepaths.DashboardCertLocation())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if isCerticateExpiring(certificate) {
+			t.Errorf("Test: '%s'' FAILED : certificate is expiring", name)
+		}
+		if test.regenerated {
+			if err := certificate.VerifyHostname("localhost"); err != nil {
+				t.Errorf("Test: '%s'' FAILED : certificate is not valid for localhost: %s", name, err.Error())
+			}
+			if err := certificate.VerifyHostname("127.0.0.1"); err != nil {
+				t.Errorf("Test: '%s'' FAILED : certificate is not valid for 127.0.0.1: %s", name, err.Error())
+			}
+		}
+	}
+}
@@ -0,0 +1,243 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet"
+	"github.com/apache/arrow/go/v14/parquet/compress"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// the number of rows written in each record batch (and each parquet row group)
+// rows are streamed from the result in batches of this size, so the full result set is never held in memory
+const columnarBatchSize = 10000
+
+// recordWriter is implemented by the arrow IPC stream writer and the parquet file writer
+type recordWriter interface {
+	Write(rec arrow.Record) error
+	Close() error
+}
+
+func displayParquet(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
+	return displayUsingWriter(ctx, os.Stdout, result, writeParquet)
+}
+
+func displayArrow(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
+	return displayUsingWriter(ctx, os.Stdout, result, writeArrow)
+}
+
+// writeParquet writes the result as a parquet file
+func writeParquet(w io.Writer, result *queryresult.Result) (int, error) {
+	return writeColumnar(w, result, newParquetRecordWriter)
+}
+
+// writeArrow writes the result as an arrow IPC stream
+func writeArrow(w io.Writer, result *queryresult.Result) (int, error) {
+	return writeColumnar(w, result, newArrowRecordWriter)
+}
+
+func newParquetRecordWriter(schema *arrow.Schema, w io.Writer) (recordWriter, error) {
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	return pqarrow.NewFileWriter(schema, w, props, pqarrow.DefaultWriterProps())
+}
+
+// newArrowRecordWriter returns an arrow IPC stream writer
+// (the IPC file format requires a seekable writer, so cannot be streamed to stdout)
+func newArrowRecordWriter(schema *arrow.Schema, w io.Writer) (recordWriter, error) {
+	return ipc.NewWriter(w, ipc.WithSchema(schema)), nil
+}
+
+// writeColumnar streams the result rows to a columnar writer in record batches of columnarBatchSize rows
+func writeColumnar(w io.Writer, result *queryresult.Result, newWriter func(*arrow.Schema, io.Writer) (recordWriter, error)) (int, error) {
+	schema := arrowSchema(result.Cols)
+	writer, err := newWriter(schema, w)
+	if err != nil {
+		return 0, err
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+
+	// writeBatch writes the rows appended to the builder as a record batch
+	writeBatch := func() error {
+		rec := builder.NewRecord()
+		defer rec.Release()
+		if rec.NumRows() == 0 {
+			return nil
+		}
+		return writer.Write(rec)
+	}
+
+	var writeErr error
+	batchRows := 0
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		for idx, col := range result.Cols {
+			if err := appendArrowValue(builder.Field(idx), row[idx], col); err != nil {
+				writeErr = err
+				return
+			}
+		}
+		batchRows++
+		if batchRows == columnarBatchSize {
+			writeErr = writeBatch()
+			batchRows = 0
+		}
+	}
+
+	// call this function for each row
+	count, err := iterateResults(result, rowFunc)
+	if err == nil {
+		err = writeErr
+	}
+	if err == nil {
+		// write any remaining rows
+		err = writeBatch()
+	}
+	// always close the writer - this writes the file footer
+	if closeErr := writer.Close(); err == nil {
+		err = closeErr
+	}
+	return count, err
+}
+
+// arrowSchema returns the arrow schema for the given result columns
+// the arrow type of each column is determined from the column data type -
+// types with no direct arrow equivalent are represented as strings
+func arrowSchema(cols []*queryresult.ColumnDef) *arrow.Schema {
+	fields := make([]arrow.Field, len(cols))
+	for i, col := range cols {
+		fields[i] = arrow.Field{
+			Name:     col.Name,
+			Type:     arrowDataType(col.DataType),
+			Nullable: true,
+		}
+	}
+	return arrow.NewSchema(fields, nil)
+}
+
+func arrowDataType(dataType string) arrow.DataType {
+	switch dataType {
+	case "BOOL":
+		return arrow.FixedWidthTypes.Boolean
+	case "INT2":
+		return arrow.PrimitiveTypes.Int16
+	case "INT4":
+		return arrow.PrimitiveTypes.Int32
+	case "INT8":
+		return arrow.PrimitiveTypes.Int64
+	case "FLOAT4":
+		return arrow.PrimitiveTypes.Float32
+	case "FLOAT8":
+		return arrow.PrimitiveTypes.Float64
+	case "TIMESTAMPTZ":
+		return &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"}
+	case "TIMESTAMP":
+		return &arrow.TimestampType{Unit: arrow.Microsecond}
+	case "DATE":
+		return arrow.FixedWidthTypes.Date32
+	default:
+		// NOTE: numeric values are written as strings to avoid losing precision
+		// and json values are written as their JSON string representation
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendArrowValue appends the column value to the builder, converting it to the arrow type of the builder
+func appendArrowValue(builder array.Builder, val interface{}, col *queryresult.ColumnDef) error {
+	if val == nil {
+		builder.AppendNull()
+		return nil
+	}
+
+	var ok = true
+	switch b := builder.(type) {
+	case *array.BooleanBuilder:
+		var v bool
+		if v, ok = val.(bool); ok {
+			b.Append(v)
+		}
+	case *array.Int16Builder:
+		var v int64
+		if v, ok = asInt64(val); ok {
+			b.Append(int16(v))
+		}
+	case *array.Int32Builder:
+		var v int64
+		if v, ok = asInt64(val); ok {
+			b.Append(int32(v))
+		}
+	case *array.Int64Builder:
+		var v int64
+		if v, ok = asInt64(val); ok {
+			b.Append(v)
+		}
+	case *array.Float32Builder:
+		var v float64
+		if v, ok = asFloat64(val); ok {
+			b.Append(float32(v))
+		}
+	case *array.Float64Builder:
+		var v float64
+		if v, ok = asFloat64(val); ok {
+			b.Append(v)
+		}
+	case *array.TimestampBuilder:
+		var t time.Time
+		if t, ok = val.(time.Time); ok {
+			b.Append(arrow.Timestamp(t.UnixMicro()))
+		}
+	case *array.Date32Builder:
+		var t time.Time
+		if t, ok = val.(time.Time); ok {
+			b.Append(arrow.Date32FromTime(t))
+		}
+	case *array.StringBuilder:
+		str, err := ColumnValueAsString(val, col)
+		if err != nil {
+			return err
+		}
+		b.Append(str)
+	default:
+		return fmt.Errorf("unsupported arrow type %s for column '%s'", builder.Type(), col.Name)
+	}
+	if !ok {
+		return fmt.Errorf("unexpected value of type %T for %s column '%s'", val, col.DataType, col.Name)
+	}
+	return nil
+}
+
+func asInt64(val interface{}) (int64, bool) {
+	switch v := val.(type) {
+	case int:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	}
+	return 0, false
+}
+
+func asFloat64(val interface{}) (float64, bool) {
+	switch v := val.(type) {
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
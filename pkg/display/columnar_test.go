@@ -0,0 +1,282 @@
+package display
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/apache/arrow/go/v14/parquet/file"
+	"github.com/apache/arrow/go/v14/parquet/pqarrow"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// columnarTable is a columnar result which has been read back, with the number of record batches (or row groups)
+type columnarTable struct {
+	table   arrow.Table
+	batches int
+}
+
+type columnarFormat struct {
+	write func(w *bytes.Buffer, result *queryresult.Result) (int, error)
+	read  func(t *testing.T, data []byte) *columnarTable
+}
+
+var columnarFormats = map[string]columnarFormat{
+	"parquet": {
+		write: func(w *bytes.Buffer, result *queryresult.Result) (int, error) { return writeParquet(w, result) },
+		read:  readParquet,
+	},
+	"arrow": {
+		write: func(w *bytes.Buffer, result *queryresult.Result) (int, error) { return writeArrow(w, result) },
+		read:  readArrow,
+	},
+}
+
+func readParquet(t *testing.T, data []byte) *columnarTable {
+	parquetReader, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer parquetReader.Close()
+	reader, err := pqarrow.NewFileReader(parquetReader, pqarrow.ArrowReadProperties{}, memory.DefaultAllocator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	table, err := reader.ReadTable(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &columnarTable{table: table, batches: parquetReader.NumRowGroups()}
+}
+
+func readArrow(t *testing.T, data []byte) *columnarTable {
+	reader, err := ipc.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Release()
+	var records []arrow.Record
+	for reader.Next() {
+		rec := reader.Record()
+		rec.Retain()
+		records = append(records, rec)
+	}
+	if err := reader.Err(); err != nil {
+		t.Fatal(err)
+	}
+	return &columnarTable{table: array.NewTableFromRecords(reader.Schema(), records), batches: len(records)}
+}
+
+// newTestResult returns a result which streams the given rows
+func newTestResult(cols []*queryresult.ColumnDef, rows [][]interface{}) *queryresult.Result {
+	result := queryresult.NewResult(cols)
+	go func() {
+		for _, row := range rows {
+			result.StreamRow(row)
+		}
+		result.Close()
+	}()
+	return result
+}
+
+// columnValues returns the values of a column as strings - nulls are returned as 'null'
+func columnValues(col *arrow.Column) []string {
+	var res []string
+	for _, chunk := range col.Data().Chunks() {
+		for i := 0; i < chunk.Len(); i++ {
+			if chunk.IsNull(i) {
+				res = append(res, "null")
+				continue
+			}
+			var value interface{}
+			switch a := chunk.(type) {
+			case *array.Timestamp:
+				value = a.Value(i).ToTime(arrow.Microsecond).Format(time.RFC3339Nano)
+			case *array.Date32:
+				value = a.Value(i).ToTime().Format(time.DateOnly)
+			default:
+				value = a.GetOneForMarshal(i)
+			}
+			res = append(res, fmt.Sprintf("%v", value))
+		}
+	}
+	return res
+}
+
+type columnarTypeTest struct {
+	dataType     string
+	value        interface{}
+	expectedType arrow.DataType
+	// the type read back from parquet, if this differs from expectedType
+	expectedParquetType arrow.DataType
+	expected            string
+}
+
+var testCasesColumnarTypes = map[string]columnarTypeTest{
+	"bool": {
+		dataType:     "BOOL",
+		value:        true,
+		expectedType: arrow.FixedWidthTypes.Boolean,
+		expected:     "true",
+	},
+	"int2": {
+		dataType:     "INT2",
+		value:        int16(-12),
+		expectedType: arrow.PrimitiveTypes.Int16,
+		expected:     "-12",
+	},
+	"int4": {
+		dataType:     "INT4",
+		value:        int32(123456),
+		expectedType: arrow.PrimitiveTypes.Int32,
+		expected:     "123456",
+	},
+	"int8": {
+		dataType:     "INT8",
+		value:        int64(9007199254740993),
+		expectedType: arrow.PrimitiveTypes.Int64,
+		expected:     "9007199254740993",
+	},
+	"float4": {
+		dataType:     "FLOAT4",
+		value:        float32(1.5),
+		expectedType: arrow.PrimitiveTypes.Float32,
+		expected:     "1.5",
+	},
+	"float8": {
+		dataType:     "FLOAT8",
+		value:        float64(-0.25),
+		expectedType: arrow.PrimitiveTypes.Float64,
+		expected:     "-0.25",
+	},
+	"timestamp": {
+		dataType:     "TIMESTAMP",
+		value:        time.Date(2024, 3, 1, 9, 30, 15, 123456000, time.UTC),
+		expectedType: &arrow.TimestampType{Unit: arrow.Microsecond},
+		// the parquet writer stores timestamps without a time zone as adjusted to UTC, so they are read back as UTC
+		expectedParquetType: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"},
+		expected:            "2024-03-01T09:30:15.123456Z",
+	},
+	"timestamptz": {
+		dataType:     "TIMESTAMPTZ",
+		value:        time.Date(2024, 3, 1, 10, 30, 15, 0, time.FixedZone("CET", 3600)),
+		expectedType: &arrow.TimestampType{Unit: arrow.Microsecond, TimeZone: "UTC"},
+		expected:     "2024-03-01T09:30:15Z",
+	},
+	"date": {
+		dataType:     "DATE",
+		value:        time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+		expectedType: arrow.FixedWidthTypes.Date32,
+		expected:     "2024-02-29",
+	},
+	"text": {
+		dataType:     "TEXT",
+		value:        "hello",
+		expectedType: arrow.BinaryTypes.String,
+		expected:     "hello",
+	},
+	"numeric is a string": {
+		dataType:     "NUMERIC",
+		value:        "12345678901234567890.123",
+		expectedType: arrow.BinaryTypes.String,
+		expected:     "12345678901234567890.123",
+	},
+	"jsonb is a json string": {
+		dataType:     "JSONB",
+		value:        map[string]interface{}{"a": 1},
+		expectedType: arrow.BinaryTypes.String,
+		expected:     `{"a":1}`,
+	},
+}
+
+// TestColumnarTypes writes a column of each type, with a value and a null, and reads it back
+func TestColumnarTypes(t *testing.T) {
+	for formatName, format := range columnarFormats {
+		for name, test := range testCasesColumnarTypes {
+			cols := []*queryresult.ColumnDef{{Name: "c", DataType: test.dataType}}
+			result := newTestResult(cols, [][]interface{}{{test.value}, {nil}})
+
+			var buf bytes.Buffer
+			count, err := format.write(&buf, result)
+			if err != nil {
+				t.Errorf("Test: '%s %s'' FAILED : unexpected error: %s", formatName, name, err.Error())
+				continue
+			}
+			if count != 2 {
+				t.Errorf("Test: '%s %s'' FAILED : expected 2 rows to be written, got %d", formatName, name, count)
+			}
+
+			res := format.read(t, buf.Bytes())
+			expectedType := test.expectedType
+			if formatName == "parquet" && test.expectedParquetType != nil {
+				expectedType = test.expectedParquetType
+			}
+			if actual := res.table.Schema().Field(0).Type; !arrow.TypeEqual(actual, expectedType) {
+				t.Errorf("Test: '%s %s'' FAILED : expected type %s, got %s", formatName, name, expectedType, actual)
+			}
+			expected := []string{test.expected, "null"}
+			if actual := columnValues(res.table.Column(0)); !reflect.DeepEqual(actual, expected) {
+				t.Errorf("Test: '%s %s'' FAILED : expected %v, got %v", formatName, name, expected, actual)
+			}
+			res.table.Release()
+		}
+	}
+}
+
+// TestColumnarBatches writes a result larger than one record batch
+func TestColumnarBatches(t *testing.T) {
+	rowCount := 2*columnarBatchSize + 1
+	cols := []*queryresult.ColumnDef{{Name: "id", DataType: "INT8"}, {Name: "name", DataType: "TEXT"}}
+
+	for formatName, format := range columnarFormats {
+		rows := make([][]interface{}, rowCount)
+		for i := range rows {
+			rows[i] = []interface{}{int64(i), fmt.Sprintf("row %d", i)}
+		}
+
+		var buf bytes.Buffer
+		count, err := format.write(&buf, newTestResult(cols, rows))
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", formatName, err.Error())
+			continue
+		}
+		if count != rowCount {
+			t.Errorf("Test: '%s'' FAILED : expected %d rows to be written, got %d", formatName, rowCount, count)
+		}
+
+		res := format.read(t, buf.Bytes())
+		if res.batches != 3 {
+			t.Errorf("Test: '%s'' FAILED : expected 3 batches, got %d", formatName, res.batches)
+		}
+		if res.table.NumRows() != int64(rowCount) {
+			t.Errorf("Test: '%s'' FAILED : expected %d rows, got %d", formatName, rowCount, res.table.NumRows())
+		}
+		// check the rows are in order across the batches
+		ids := columnValues(res.table.Column(0))
+		names := columnValues(res.table.Column(1))
+		for _, i := range []int{0, columnarBatchSize - 1, columnarBatchSize, rowCount - 1} {
+			if ids[i] != fmt.Sprint(i) || names[i] != fmt.Sprintf("row %d", i) {
+				t.Errorf("Test: '%s'' FAILED : expected row %d, got %s, %s", formatName, i, ids[i], names[i])
+			}
+		}
+		res.table.Release()
+	}
+}
+
+// TestColumnarInvalidValue writes a value which does not match the column type
+func TestColumnarInvalidValue(t *testing.T) {
+	for formatName, format := range columnarFormats {
+		cols := []*queryresult.ColumnDef{{Name: "c", DataType: "INT4"}}
+		var buf bytes.Buffer
+		if _, err := format.write(&buf, newTestResult(cols, [][]interface{}{{"not an int"}})); err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected an error", formatName)
+		}
+	}
+}
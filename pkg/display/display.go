@@ -39,16 +39,23 @@ func ShowOutput(ctx context.Context, result *queryresult.Result, opts ...Display
 
 	var timingResult *queryresult.TimingResult
 
-	outputFormat := cmdconfig.Viper().GetString(constants.ArgOutput)
-	switch outputFormat {
-	case constants.OutputFormatJSON:
-		rowErrors, timingResult = displayJSON(ctx, result)
-	case constants.OutputFormatCSV:
-		rowErrors, timingResult = displayCSV(ctx, result)
-	case constants.OutputFormatLine:
-		rowErrors, timingResult = displayLine(ctx, result)
-	case constants.OutputFormatTable:
-		rowErrors, timingResult = displayTable(ctx, result)
+	outputFormat := config.outputFormat
+	writer := resultWriterForFormat(outputFormat)
+	if config.rowColors != nil && outputFormat == constants.OutputFormatTable {
+		writer = coloredTableWriter(config.rowColors)
+	}
+	switch {
+	case config.outputWriter != nil:
+		// write the result to the output writer rather than displaying it
+		rowErrors, timingResult = displayUsingWriter(ctx, config.outputWriter, result, writer)
+	case config.alwaysPage:
+		outbuf := bytes.NewBufferString("")
+		rowErrors, timingResult = displayUsingWriter(ctx, outbuf, result, writer)
+		showInPager(ctx, outbuf.String())
+	case config.rowColors != nil:
+		rowErrors, timingResult = displayUsingWriter(ctx, os.Stdout, result, writer)
+	default:
+		rowErrors, timingResult = displayResult(ctx, result, outputFormat)
 	}
 
 	// show timing
@@ -64,6 +71,59 @@ func ShowOutput(ctx context.Context, result *queryresult.Result, opts ...Display
 	return rowErrors
 }
 
+// displayResult displays the result on stdout in the given format
+func displayResult(ctx context.Context, result *queryresult.Result, outputFormat string) (rowErrors int, timingResult *queryresult.TimingResult) {
+	switch outputFormat {
+	case constants.OutputFormatJSON:
+		rowErrors, timingResult = displayJSON(ctx, result)
+	case constants.OutputFormatCSV:
+		rowErrors, timingResult = displayCSV(ctx, result)
+	case constants.OutputFormatLine:
+		rowErrors, timingResult = displayLine(ctx, result)
+	case constants.OutputFormatTable:
+		rowErrors, timingResult = displayTable(ctx, result)
+	case constants.OutputFormatParquet:
+		rowErrors, timingResult = displayParquet(ctx, result)
+	case constants.OutputFormatArrow:
+		rowErrors, timingResult = displayArrow(ctx, result)
+	case constants.OutputFormatMD:
+		rowErrors, timingResult = displayUsingWriter(ctx, os.Stdout, result, writeMarkdown)
+	case constants.OutputFormatHTML:
+		rowErrors, timingResult = displayUsingWriter(ctx, os.Stdout, result, writeHTML)
+	case constants.OutputFormatJSONL:
+		rowErrors, timingResult = displayUsingWriter(ctx, os.Stdout, result, writeJSONL)
+	case constants.OutputFormatYAML:
+		rowErrors, timingResult = displayUsingWriter(ctx, os.Stdout, result, writeYAML)
+	}
+	return rowErrors, timingResult
+}
+
+// resultWriterForFormat returns the function used to write a result in the given output format
+func resultWriterForFormat(outputFormat string) resultWriterFunc {
+	switch outputFormat {
+	case constants.OutputFormatJSON:
+		return writeJSON
+	case constants.OutputFormatCSV:
+		return writeCSV
+	case constants.OutputFormatLine:
+		return writeLine
+	case constants.OutputFormatParquet:
+		return writeParquet
+	case constants.OutputFormatArrow:
+		return writeArrow
+	case constants.OutputFormatMD:
+		return writeMarkdown
+	case constants.OutputFormatHTML:
+		return writeHTML
+	case constants.OutputFormatJSONL:
+		return writeJSONL
+	case constants.OutputFormatYAML:
+		return writeYAML
+	default:
+		return writeTable
+	}
+}
+
 type ShowWrappedTableOptions struct {
 	AutoMerge        bool
 	HideEmptyColumns bool
@@ -197,20 +257,8 @@ func newJSONOutput() *jsonOutput {
 
 func displayJSON(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
 	rowErrors := 0
-	jsonOutput := newJSONOutput()
 
-	// define function to add each row to the JSON output
-	rowFunc := func(row []interface{}, result *queryresult.Result) {
-		record := map[string]interface{}{}
-		for idx, col := range result.Cols {
-			value, _ := ParseJSONOutputColumnValue(row[idx], col)
-			record[col.Name] = value
-		}
-		jsonOutput.Rows = append(jsonOutput.Rows, record)
-	}
-
-	// call this function for each row
-	count, err := iterateResults(result, rowFunc)
+	jsonOutput, count, err := buildJSONOutput(result)
 	if err != nil {
 		error_helpers.ShowError(ctx, err)
 		rowErrors++
@@ -221,19 +269,50 @@ func displayJSON(ctx context.Context, result *queryresult.Result) (int, *queryre
 	jsonOutput.Metadata = getTiming(result, count)
 
 	// display the JSON
-	encoder := json.NewEncoder(os.Stdout)
-	encoder.SetIndent("", " ")
-	encoder.SetEscapeHTML(false)
-	if err := encoder.Encode(jsonOutput); err != nil {
+	if err := writeJSONOutput(os.Stdout, jsonOutput); err != nil {
 		fmt.Print("Error displaying result as JSON", err)
 		return 0, nil
 	}
 	return rowErrors, jsonOutput.Metadata
 }
 
+// writeJSON writes the result rows as JSON (with no timing metadata)
+func writeJSON(w io.Writer, result *queryresult.Result) (int, error) {
+	jsonOutput, count, err := buildJSONOutput(result)
+	if err != nil {
+		return count, err
+	}
+	return count, writeJSONOutput(w, jsonOutput)
+}
+
+// buildJSONOutput reads all rows of the result into a jsonOutput, returning the output and the row count
+func buildJSONOutput(result *queryresult.Result) (*jsonOutput, int, error) {
+	jsonOutput := newJSONOutput()
+
+	// define function to add each row to the JSON output
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		jsonOutput.Rows = append(jsonOutput.Rows, jsonRecord(row, result.Cols))
+	}
+
+	// call this function for each row
+	count, err := iterateResults(result, rowFunc)
+	return jsonOutput, count, err
+}
+
+func writeJSONOutput(w io.Writer, jsonOutput *jsonOutput) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", " ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(jsonOutput)
+}
+
 func displayCSV(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
-	rowErrors := 0
-	csvWriter := csv.NewWriter(os.Stdout)
+	return displayUsingWriter(ctx, os.Stdout, result, writeCSV)
+}
+
+// writeCSV writes the result as CSV, using the configured separator and header settings
+func writeCSV(w io.Writer, result *queryresult.Result) (int, error) {
+	csvWriter := csv.NewWriter(w)
 	csvWriter.Comma = []rune(cmdconfig.Viper().GetString(constants.ArgSeparator))[0]
 
 	if cmdconfig.Viper().GetBool(constants.ArgHeader) {
@@ -250,25 +329,23 @@ func displayCSV(ctx context.Context, result *queryresult.Result) (int, *queryres
 	// call this function for each row
 	count, err := iterateResults(result, rowFunc)
 	if err != nil {
-		error_helpers.ShowError(ctx, err)
-		rowErrors++
-		return rowErrors, nil
+		return count, err
 	}
 
 	csvWriter.Flush()
-	if csvWriter.Error() != nil {
-		error_helpers.ShowErrorWithMessage(ctx, csvWriter.Error(), "unable to print csv")
+	if err := csvWriter.Error(); err != nil {
+		return count, fmt.Errorf("unable to write csv: %s", err.Error())
 	}
-
-	// now we have iterated the rows, get the timing
-	timingResult := getTiming(result, count)
-
-	return rowErrors, timingResult
+	return count, nil
 }
 
 func displayLine(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
+	return displayUsingWriter(ctx, os.Stdout, result, writeLine)
+}
 
-	maxColNameLength, rowErrors := 0, 0
+// writeLine writes the result in line format - one line per column of each record
+func writeLine(w io.Writer, result *queryresult.Result) (int, error) {
+	maxColNameLength := 0
 	for _, col := range result.Cols {
 		thisLength := utf8.RuneCountInString(col.Name)
 		if thisLength > maxColNameLength {
@@ -291,26 +368,26 @@ func displayLine(ctx context.Context, result *queryresult.Result) (int, *queryre
 		lineFormat := fmt.Sprintf("%%-%ds | %%s\n", maxColNameLength)
 		multiLineFormat := fmt.Sprintf("%%-%ds | %%-%ds", maxColNameLength, requiredTerminalColumnsForValuesOfRecord)
 
-		fmt.Printf("-[ RECORD %-2d ]%s\n", (itemIdx + 1), strings.Repeat("-", 75))
+		fmt.Fprintf(w, "-[ RECORD %-2d ]%s\n", (itemIdx + 1), strings.Repeat("-", 75))
 		for idx, column := range recordAsString {
 			lines := strings.Split(column, "\n")
 			if len(lines) == 1 {
-				fmt.Printf(lineFormat, result.Cols[idx].Name, lines[0])
+				fmt.Fprintf(w, lineFormat, result.Cols[idx].Name, lines[0])
 			} else {
 				for lineIdx, line := range lines {
 					if lineIdx == 0 {
 						// the first line
-						fmt.Printf(multiLineFormat, result.Cols[idx].Name, line)
+						fmt.Fprintf(w, multiLineFormat, result.Cols[idx].Name, line)
 					} else {
 						// next lines
-						fmt.Printf(multiLineFormat, "", line)
+						fmt.Fprintf(w, multiLineFormat, "", line)
 					}
 
 					// is this not the last line of value?
 					if lineIdx < len(lines)-1 {
-						fmt.Printf(" +\n")
+						fmt.Fprintf(w, " +\n")
 					} else {
-						fmt.Printf("\n")
+						fmt.Fprintf(w, "\n")
 					}
 
 				}
@@ -321,26 +398,49 @@ func displayLine(ctx context.Context, result *queryresult.Result) (int, *queryre
 	}
 
 	// call this function for each row
-	count, err := iterateResults(result, rowFunc)
+	return iterateResults(result, rowFunc)
+}
+
+func displayTable(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
+	rowErrors := 0
+	// the buffer to put the output data in
+	outbuf := bytes.NewBufferString("")
+
+	count, err := writeTable(outbuf, result)
 	if err != nil {
+		// display the error
+		fmt.Println()
 		error_helpers.ShowError(ctx, err)
 		rowErrors++
-		return rowErrors, nil
+		fmt.Println()
 	}
 
+	// page out the table
+	ShowPaged(ctx, outbuf.String())
+
 	// now we have iterated the rows, get the timing
 	timingResult := getTiming(result, count)
+
 	return rowErrors, timingResult
 }
 
-func displayTable(ctx context.Context, result *queryresult.Result) (int, *queryresult.TimingResult) {
-	rowErrors := 0
-	// the buffer to put the output data in
-	outbuf := bytes.NewBufferString("")
+// writeTable writes the result as a table
+// NOTE: the table is rendered even if there is an error iterating the rows
+func writeTable(w io.Writer, result *queryresult.Result) (int, error) {
+	return writeColoredTable(w, result, nil)
+}
+
+// coloredTableWriter returns a resultWriterFunc which writes a table, displaying each row in the colors returned by rowColors
+func coloredTableWriter(rowColors RowColorFunc) resultWriterFunc {
+	return func(w io.Writer, result *queryresult.Result) (int, error) {
+		return writeColoredTable(w, result, rowColors)
+	}
+}
 
+func writeColoredTable(w io.Writer, result *queryresult.Result, rowColors RowColorFunc) (int, error) {
 	// the table
 	t := table.NewWriter()
-	t.SetOutputMirror(outbuf)
+	t.SetOutputMirror(w)
 	t.SetStyle(table.StyleDefault)
 	t.Style().Format.Header = text.FormatDefault
 
@@ -362,8 +462,14 @@ func displayTable(ctx context.Context, result *queryresult.Result) (int, *queryr
 	}
 
 	// define a function to execute for each row
+	rowIdx := 0
 	rowFunc := func(row []interface{}, result *queryresult.Result) {
 		rowAsString, _ := ColumnValuesAsString(row, result.Cols)
+		var colors text.Colors
+		if rowColors != nil {
+			colors = rowColors(rowIdx)
+		}
+		rowIdx++
 		rowObj := table.Row{}
 		for _, col := range rowAsString {
 			// trim out non-displayable code-points in string
@@ -375,6 +481,9 @@ func displayTable(ctx context.Context, result *queryresult.Result) (int, *queryr
 				}
 				return -1
 			}, col)
+			if len(colors) > 0 {
+				col = colors.Sprint(col)
+			}
 			rowObj = append(rowObj, col)
 		}
 		t.AppendRow(rowObj)
@@ -382,23 +491,11 @@ func displayTable(ctx context.Context, result *queryresult.Result) (int, *queryr
 
 	// iterate each row, adding each to the table
 	count, err := iterateResults(result, rowFunc)
-	if err != nil {
-		// display the error
-		fmt.Println()
-		error_helpers.ShowError(ctx, err)
-		rowErrors++
-		fmt.Println()
-	}
-	// write out the table to the buffer
-	t.Render()
-
-	// page out the table
-	ShowPaged(ctx, outbuf.String())
 
-	// now we have iterated the rows, get the timing
-	timingResult := getTiming(result, count)
+	// write out the table
+	t.Render()
 
-	return rowErrors, timingResult
+	return count, err
 }
 
 func getTiming(result *queryresult.Result, count int) *queryresult.TimingResult {
@@ -600,3 +697,13 @@ func DisplayErrorTiming(t time.Time) {
 	}
 	fmt.Println(sb.String())
 }
+
+// ShowStatementHeader shows a header identifying the query or statement whose result follows, when running
+// several queries or the statements of a script - only the first line of the statement is shown
+func ShowStatementHeader(idx, count int, statement string) {
+	statement = strings.TrimSpace(statement)
+	if lineEnd := strings.Index(statement, "\n"); lineEnd != -1 {
+		statement = statement[:lineEnd] + "…"
+	}
+	fmt.Println(constants.Bold(fmt.Sprintf("[%d/%d] %s", idx+1, count, statement)))
+}
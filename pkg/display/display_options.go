@@ -1,19 +1,34 @@
 package display
 
 import (
+	"io"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+
 	"github.com/turbot/steampipe/pkg/cmdconfig"
 	"github.com/turbot/steampipe/pkg/constants"
 )
 
 type displayConfiguration struct {
-	timing string
+	timing       string
+	outputFormat string
+	// if set, the result is written to this writer rather than displayed
+	outputWriter io.Writer
+	// if set, the result is always displayed in the pager
+	alwaysPage bool
+	// if set, the rows of table output are displayed using the colors returned by this function
+	rowColors RowColorFunc
 }
 
+// RowColorFunc returns the colors used to display the row at the given index
+type RowColorFunc func(rowIdx int) text.Colors
+
 // newDisplayConfiguration creates a default configuration with timing set to
 // true if both --timing is not 'off' and --output is table
 func newDisplayConfiguration() *displayConfiguration {
 	return &displayConfiguration{
-		timing: cmdconfig.Viper().GetString(constants.ArgTiming),
+		timing:       cmdconfig.Viper().GetString(constants.ArgTiming),
+		outputFormat: cmdconfig.Viper().GetString(constants.ArgOutput),
 	}
 }
 
@@ -25,3 +40,32 @@ func WithTimingDisabled() DisplayOption {
 		o.timing = constants.ArgOff
 	}
 }
+
+// WithOutputWriter writes the result to the given writer (e.g. a file) rather than displaying it
+// the result is written in the current output format
+func WithOutputWriter(w io.Writer) DisplayOption {
+	return func(o *displayConfiguration) {
+		o.outputWriter = w
+	}
+}
+
+// WithOutputFormat displays the result in the given format, rather than the configured output format
+func WithOutputFormat(outputFormat string) DisplayOption {
+	return func(o *displayConfiguration) {
+		o.outputFormat = outputFormat
+	}
+}
+
+// WithPager displays the result in the pager, regardless of its size
+func WithPager() DisplayOption {
+	return func(o *displayConfiguration) {
+		o.alwaysPage = true
+	}
+}
+
+// WithRowColors displays the rows of table output using the given colors, e.g. to highlight changed rows
+func WithRowColors(rowColors RowColorFunc) DisplayOption {
+	return func(o *displayConfiguration) {
+		o.rowColors = rowColors
+	}
+}
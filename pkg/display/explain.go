@@ -0,0 +1,152 @@
+package display
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/query/queryexplain"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+)
+
+// ShowExplanation displays the query plan as a tree, with the plugin scans of each foreign scan node
+func ShowExplanation(explanation *queryexplain.Explanation) {
+	fmt.Print(buildExplanationString(explanation))
+}
+
+func buildExplanationString(explanation *queryexplain.Explanation) string {
+	var sb strings.Builder
+	p := message.NewPrinter(language.English)
+
+	writePlanNode(&sb, p, explanation.Plan, "", "", explanation.Analyzed)
+
+	if len(explanation.UnmatchedScans) > 0 {
+		sb.WriteString("\nOther scans:\n")
+		for _, scan := range explanation.UnmatchedScans {
+			sb.WriteString(fmt.Sprintf("  %s\n", scanString(p, scan)))
+		}
+	}
+
+	sb.WriteString("\n")
+	if explanation.PlanningTime != nil {
+		sb.WriteString(p.Sprintf("Planning time: %.3fms\n", *explanation.PlanningTime))
+	}
+	if explanation.ExecutionTime != nil {
+		sb.WriteString(p.Sprintf("Execution time: %.3fms\n", *explanation.ExecutionTime))
+	}
+	if !explanation.Analyzed {
+		sb.WriteString("The query was not executed - analyze the query to see the quals pushed down to plugins and the cache hits of each scan.\n")
+	}
+	return sb.String()
+}
+
+// writePlanNode writes the node and its children
+// prefix is written before the node title, and childPrefix before all other lines of the node and its children
+func writePlanNode(sb *strings.Builder, p *message.Printer, node *queryexplain.PlanNode, prefix, childPrefix string, analyzed bool) {
+	sb.WriteString(prefix)
+	sb.WriteString(planNodeTitle(p, node))
+	sb.WriteString("\n")
+
+	// the details are indented to align with the children
+	detailPrefix := childPrefix + "│  "
+	if len(node.Plans) == 0 {
+		detailPrefix = childPrefix + "   "
+	}
+	for _, detail := range planNodeDetails(p, node, analyzed) {
+		sb.WriteString(detailPrefix)
+		sb.WriteString(detail)
+		sb.WriteString("\n")
+	}
+
+	for i, child := range node.Plans {
+		if i == len(node.Plans)-1 {
+			writePlanNode(sb, p, child, childPrefix+"└─ ", childPrefix+"   ", analyzed)
+		} else {
+			writePlanNode(sb, p, child, childPrefix+"├─ ", childPrefix+"│  ", analyzed)
+		}
+	}
+}
+
+func planNodeTitle(p *message.Printer, node *queryexplain.PlanNode) string {
+	var sb strings.Builder
+	title := node.NodeType
+	if node.JoinType != "" && node.JoinType != "Inner" {
+		title = fmt.Sprintf("%s %s", title, node.JoinType)
+	}
+	if node.RelationName != "" {
+		relation := node.RelationName
+		if node.Schema != "" {
+			relation = fmt.Sprintf("%s.%s", node.Schema, relation)
+		}
+		title = fmt.Sprintf("%s on %s", title, relation)
+		if node.Alias != "" && node.Alias != node.RelationName {
+			title = fmt.Sprintf("%s %s", title, node.Alias)
+		}
+	}
+	if node.IsForeignScan() {
+		sb.WriteString(constants.Bold(title).String())
+	} else {
+		sb.WriteString(title)
+	}
+
+	sb.WriteString(p.Sprintf(" (cost=%.2f..%.2f rows=%.0f)", node.StartupCost, node.TotalCost, node.PlanRows))
+	if node.ActualTotalTime != nil && node.ActualRows != nil && node.ActualLoops != nil {
+		sb.WriteString(p.Sprintf(" (actual time=%.3fms rows=%.0f loops=%.0f)", *node.ActualTotalTime, *node.ActualRows, *node.ActualLoops))
+	}
+	return sb.String()
+}
+
+func planNodeDetails(p *message.Printer, node *queryexplain.PlanNode, analyzed bool) []string {
+	var details []string
+	if node.HashCond != "" {
+		details = append(details, fmt.Sprintf("Hash Cond: %s", node.HashCond))
+	}
+	if node.IndexCond != "" {
+		details = append(details, fmt.Sprintf("Index Cond: %s", node.IndexCond))
+	}
+	if node.JoinFilter != "" {
+		details = append(details, fmt.Sprintf("Join Filter: %s", node.JoinFilter))
+	}
+	if len(node.SortKey) > 0 {
+		details = append(details, fmt.Sprintf("Sort Key: %s", strings.Join(node.SortKey, ", ")))
+	}
+	if node.Filter != "" {
+		details = append(details, fmt.Sprintf("Filter: %s", node.Filter))
+	}
+
+	if !node.IsForeignScan() || !analyzed {
+		return details
+	}
+
+	if len(node.Scans) == 0 {
+		details = append(details, "No plugin scans recorded")
+		return details
+	}
+	if node.IsFullScan() {
+		details = append(details, constants.BoldYellow("Full scan - no quals were pushed down to the plugin").String())
+	}
+	for _, scan := range node.Scans {
+		details = append(details, scanString(p, scan))
+	}
+	return details
+}
+
+// scanString returns a description of the plugin scan, highlighting the quals which were pushed down
+func scanString(p *message.Printer, scan *queryresult.ScanMetadataRow) string {
+	cacheString := ""
+	if scan.CacheHit {
+		cacheString = fmt.Sprintf(" %s.", constants.Green("Cache hit"))
+	}
+	qualsString := formatQuals(scan)
+	if qualsString != "" {
+		qualsString = constants.Green(qualsString).String()
+	}
+	limitString := ""
+	if scan.Limit != nil {
+		limitString = p.Sprintf(" Limit: %d.", *scan.Limit)
+	}
+	return p.Sprintf("Scan %s.%s: Time: %s. Fetched: %d. Hydrates: %d.%s%s%s",
+		scan.Table, scan.Connection, getDurationString(scan.DurationMs, p), scan.RowsFetched, scan.HydrateCalls, cacheString, qualsString, limitString)
+}
@@ -0,0 +1,165 @@
+package display
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"sigs.k8s.io/yaml"
+)
+
+// resultWriterFunc writes the result to the writer in a given format, returning the number of rows written
+type resultWriterFunc func(w io.Writer, result *queryresult.Result) (int, error)
+
+// displayUsingWriter writes the result to w using the given writer function
+func displayUsingWriter(ctx context.Context, w io.Writer, result *queryresult.Result, writeResult resultWriterFunc) (int, *queryresult.TimingResult) {
+	rowErrors := 0
+	count, err := writeResult(w, result)
+	if err != nil {
+		error_helpers.ShowError(ctx, err)
+		rowErrors++
+		return rowErrors, nil
+	}
+
+	// now we have iterated the rows, get the timing
+	timingResult := getTiming(result, count)
+
+	return rowErrors, timingResult
+}
+
+// writeMarkdown writes the result as a GitHub flavoured markdown table
+func writeMarkdown(w io.Writer, result *queryresult.Result) (int, error) {
+	escape := func(s string) string {
+		s = strings.ReplaceAll(s, "|", `\|`)
+		return strings.ReplaceAll(s, "\n", "<br>")
+	}
+
+	headers := ColumnNames(result.Cols)
+	separators := make([]string, len(headers))
+	for i, h := range headers {
+		headers[i] = escape(h)
+		separators[i] = "---"
+	}
+	if _, err := fmt.Fprintf(w, "| %s |\n| %s |\n", strings.Join(headers, " | "), strings.Join(separators, " | ")); err != nil {
+		return 0, err
+	}
+
+	var writeErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		rowAsString, _ := ColumnValuesAsString(row, result.Cols, WithNullString(""))
+		for i, s := range rowAsString {
+			rowAsString[i] = escape(s)
+		}
+		_, writeErr = fmt.Fprintf(w, "| %s |\n", strings.Join(rowAsString, " | "))
+	}
+
+	count, err := iterateResults(result, rowFunc)
+	if err == nil {
+		err = writeErr
+	}
+	return count, err
+}
+
+// writeHTML writes the result as an HTML document containing a table
+func writeHTML(w io.Writer, result *queryresult.Result) (int, error) {
+	var sb strings.Builder
+	sb.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n<title>Steampipe query result</title>\n")
+	sb.WriteString("<style>table { border-collapse: collapse; font-family: sans-serif; font-size: 14px; } th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; vertical-align: top; white-space: pre-wrap; } th { background: #f5f5f5; }</style>\n")
+	sb.WriteString("</head>\n<body>\n<table>\n<thead>\n<tr>")
+	for _, c := range result.Cols {
+		sb.WriteString(fmt.Sprintf("<th>%s</th>", html.EscapeString(c.Name)))
+	}
+	sb.WriteString("</tr>\n</thead>\n<tbody>\n")
+	if _, err := io.WriteString(w, sb.String()); err != nil {
+		return 0, err
+	}
+
+	var writeErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		rowAsString, _ := ColumnValuesAsString(row, result.Cols, WithNullString(""))
+		var rowBuilder strings.Builder
+		rowBuilder.WriteString("<tr>")
+		for _, s := range rowAsString {
+			rowBuilder.WriteString(fmt.Sprintf("<td>%s</td>", html.EscapeString(s)))
+		}
+		rowBuilder.WriteString("</tr>\n")
+		_, writeErr = io.WriteString(w, rowBuilder.String())
+	}
+
+	count, err := iterateResults(result, rowFunc)
+	if err == nil {
+		err = writeErr
+	}
+	if err != nil {
+		return count, err
+	}
+	_, err = io.WriteString(w, "</tbody>\n</table>\n</body>\n</html>\n")
+	return count, err
+}
+
+// writeJSONL writes the result as newline delimited JSON - one JSON object per row
+func writeJSONL(w io.Writer, result *queryresult.Result) (int, error) {
+	encoder := json.NewEncoder(w)
+	encoder.SetEscapeHTML(false)
+
+	var writeErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		writeErr = encoder.Encode(jsonRecord(row, result.Cols))
+	}
+
+	count, err := iterateResults(result, rowFunc)
+	if err == nil {
+		err = writeErr
+	}
+	return count, err
+}
+
+// writeYAML writes the result as a YAML sequence - one mapping per row
+func writeYAML(w io.Writer, result *queryresult.Result) (int, error) {
+	var writeErr error
+	rowFunc := func(row []interface{}, result *queryresult.Result) {
+		if writeErr != nil {
+			return
+		}
+		// marshal each row as a single item sequence, so the rows can be written as they are received
+		rowBytes, err := yaml.Marshal([]map[string]interface{}{jsonRecord(row, result.Cols)})
+		if err != nil {
+			writeErr = err
+			return
+		}
+		_, writeErr = w.Write(rowBytes)
+	}
+
+	count, err := iterateResults(result, rowFunc)
+	if err == nil {
+		err = writeErr
+	}
+	if err == nil && count == 0 {
+		_, err = io.WriteString(w, "[]\n")
+	}
+	return count, err
+}
+
+// jsonRecord converts a row into a map of column name to JSON output value
+func jsonRecord(row []interface{}, cols []*queryresult.ColumnDef) map[string]interface{} {
+	record := map[string]interface{}{}
+	for idx, col := range cols {
+		value, _ := ParseJSONOutputColumnValue(row[idx], col)
+		record[col.Name] = value
+	}
+	return record
+}
@@ -17,7 +17,16 @@ import (
 
 // ShowPaged displays the `content` in a system dependent pager
 func ShowPaged(ctx context.Context, content string) {
-	if isPagerNeeded(content) && (runtime.GOOS == "darwin" || runtime.GOOS == "linux") {
+	if isPagerNeeded(content) {
+		showInPager(ctx, content)
+	} else {
+		nullPager(content)
+	}
+}
+
+// showInPager displays the `content` in a system dependent pager, regardless of its size
+func showInPager(ctx context.Context, content string) {
+	if runtime.GOOS == "darwin" || runtime.GOOS == "linux" {
 		nixPager(ctx, content)
 	} else {
 		nullPager(content)
@@ -0,0 +1,64 @@
+package display
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/export"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// QueryExporter exports a query result using one of the display formats
+type QueryExporter struct {
+	export.ExporterBase
+	name          string
+	fileExtension string
+	writeResult   resultWriterFunc
+}
+
+func newQueryExporter(name, fileExtension string, writeResult resultWriterFunc) *QueryExporter {
+	return &QueryExporter{
+		name:          name,
+		fileExtension: fileExtension,
+		writeResult:   writeResult,
+	}
+}
+
+// QueryExporters returns the exporters for each of the supported query export formats
+func QueryExporters() []export.Exporter {
+	return []export.Exporter{
+		newQueryExporter(constants.OutputFormatCSV, ".csv", writeCSV),
+		newQueryExporter(constants.OutputFormatJSON, ".json", writeJSON),
+		newQueryExporter(constants.OutputFormatParquet, ".parquet", writeParquet),
+		newQueryExporter(constants.OutputFormatMD, ".md", writeMarkdown),
+		newQueryExporter(constants.OutputFormatHTML, ".html", writeHTML),
+		newQueryExporter(constants.OutputFormatJSONL, ".jsonl", writeJSONL),
+		newQueryExporter(constants.OutputFormatYAML, ".yaml", writeYAML),
+	}
+}
+
+func (e *QueryExporter) Export(_ context.Context, input export.ExportSourceData, filePath string) error {
+	result, ok := input.(*queryresult.Result)
+	if !ok {
+		return fmt.Errorf("QueryExporter input must be *queryresult.Result")
+	}
+
+	destination, err := os.Create(filePath)
+	if err != nil {
+		return err
+	}
+	defer destination.Close()
+
+	_, err = e.writeResult(destination, result)
+	return err
+}
+
+func (e *QueryExporter) FileExtension() string {
+	return e.fileExtension
+}
+
+func (e *QueryExporter) Name() string {
+	return e.name
+}
@@ -5,3 +5,8 @@ import "fmt"
 func ClearCurrentLine() {
 	fmt.Print("\n\033[1A\033[K")
 }
+
+// ClearScreen clears the terminal and moves the cursor to the top left
+func ClearScreen() {
+	fmt.Print("\033[H\033[2J")
+}
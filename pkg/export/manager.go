@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"path"
+	"path/filepath"
 	"strings"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
@@ -162,6 +163,31 @@ func (m *Manager) DoExport(ctx context.Context, targetName string, source Export
 	return expLocation, error_helpers.CombineErrors(errors...)
 }
 
+// DoExportToDir exports the source to each of the exports, writing any export given as a format name or a relative
+// file name to the given directory
+// it returns the paths of the exported files
+func (m *Manager) DoExportToDir(ctx context.Context, targetName string, source ExportSourceData, exports []string, dir string) ([]string, error) {
+	var errors []error
+	var exportedFiles []string
+
+	targets, err := m.resolveTargetsFromArgs(exports, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, target := range targets {
+		if !filepath.IsAbs(target.filePath) {
+			target.filePath = filepath.Join(dir, target.filePath)
+		}
+		if err := target.exporter.Export(ctx, source, target.filePath); err != nil {
+			errors = append(errors, err)
+		} else {
+			exportedFiles = append(exportedFiles, target.filePath)
+		}
+	}
+	return exportedFiles, error_helpers.CombineErrors(errors...)
+}
+
 // HasNamedExport returns true if any of the export arguments has a filename (--export=file.json) instead of the format name (--export=json)
 // panics if a target is not valid
 func (m *Manager) HasNamedExport(exports []string) bool {
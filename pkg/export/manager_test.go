@@ -2,6 +2,8 @@ package export
 
 import (
 	"context"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/turbot/steampipe/pkg/constants"
@@ -132,3 +134,28 @@ func TestDoExport(t *testing.T) {
 		}
 	}
 }
+
+func TestDoExportToDir(t *testing.T) {
+	m := NewManager()
+	m.Register(&dummyJSONExporter)
+	m.Register(&dummySPSExporter)
+
+	dir := t.TempDir()
+	absolutePath := filepath.Join(t.TempDir(), "absolute.json")
+	exportedFiles, err := m.DoExportToDir(context.Background(), "dummy_execution_name", nil, []string{"sps", "relative.json", absolutePath}, dir)
+	if err != nil {
+		t.Fatalf("DoExportToDir failed: %v", err)
+	}
+	if len(exportedFiles) != 3 {
+		t.Fatalf("expected 3 exported files - got %d: %v", len(exportedFiles), exportedFiles)
+	}
+	for _, exportedFile := range exportedFiles {
+		switch {
+		case exportedFile == absolutePath:
+		case exportedFile == filepath.Join(dir, "relative.json"):
+		case filepath.Dir(exportedFile) == dir && strings.HasSuffix(exportedFile, constants.SnapshotExtension):
+		default:
+			t.Errorf("unexpected exported file %s", exportedFile)
+		}
+	}
+}
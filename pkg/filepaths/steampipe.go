@@ -89,6 +89,31 @@ func EnsureInternalDir() string {
 	return ensureSteampipeSubDir("internal")
 }
 
+// EnsureQueryHistoryDir returns the path to the query history directory (creates if missing)
+func EnsureQueryHistoryDir() string {
+	return ensureSteampipeSubDir(filepath.Join("internal", "history"))
+}
+
+// EnsureScheduleHistoryDir returns the path to the schedule run history directory (creates if missing)
+func EnsureScheduleHistoryDir() string {
+	return ensureSteampipeSubDir(filepath.Join("internal", "schedules"))
+}
+
+// EnsureSchedulesDir returns the path to the directory scheduled runs are exported to by default (creates if missing)
+func EnsureSchedulesDir() string {
+	return ensureSteampipeSubDir("schedules")
+}
+
+// EnsureSnapshotsDir returns the path to the default snapshot library directory (creates if missing)
+func EnsureSnapshotsDir() string {
+	return ensureSteampipeSubDir("snapshots")
+}
+
+// EnsureSnapshotIndexDir returns the path to the directory containing the snapshot library indexes (creates if missing)
+func EnsureSnapshotIndexDir() string {
+	return ensureSteampipeSubDir(filepath.Join("internal", "snapshots"))
+}
+
 // EnsureBackupsDir returns the path to the backups directory (creates if missing)
 func EnsureBackupsDir() string {
 	return ensureSteampipeSubDir("backups")
@@ -194,6 +219,14 @@ func DashboardServiceStateFilePath() string {
 	return filepath.Join(EnsureInternalDir(), dashboardServerStateFileName)
 }
 
+func DashboardCertLocation() string {
+	return filepath.Join(EnsureInternalDir(), constants.DashboardCert)
+}
+
+func DashboardCertKeyLocation() string {
+	return filepath.Join(EnsureInternalDir(), constants.DashboardCertKey)
+}
+
 func StateFileName() string {
 	return stateFileName
 }
@@ -0,0 +1,216 @@
+package interactive
+
+import (
+	"regexp"
+	"strings"
+)
+
+// completionWordSeparator is the set of characters which separate the word being completed from the preceding text
+// NOTE: '.', '-', '>' and quotes are not separators, so that qualified names and jsonb paths are completed as a single word
+const completionWordSeparator = " \t\n,()=;"
+
+// statementTokenRegex splits a statement into string literals, (possibly qualified and quoted) identifiers,
+// operators we are interested in and single characters
+var statementTokenRegex = regexp.MustCompile(`'(?:[^']|'')*'?|(?:"(?:[^"]|"")*"|[\w$]+)(?:\.(?:"(?:[^"]|"")*"|[\w$]+))*|::|->>?|\S`)
+
+// jsonKeyRegex matches a word which is a jsonb key lookup with an open quote, e.g. t.tags->>'Na
+var jsonKeyRegex = regexp.MustCompile(`^(?:([\w$]+)\.)?([\w$]+)->>?'([^']*)$`)
+
+// the keywords which start a clause in which columns may be referenced
+var columnClauseKeywords = map[string]struct{}{
+	"select":    {},
+	"where":     {},
+	"on":        {},
+	"by":        {},
+	"having":    {},
+	"set":       {},
+	"returning": {},
+}
+
+// the keywords which start a clause in which columns are not referenced
+var nonColumnClauseKeywords = map[string]struct{}{
+	"from":      {},
+	"join":      {},
+	"limit":     {},
+	"offset":    {},
+	"into":      {},
+	"update":    {},
+	"values":    {},
+	"using":     {},
+	"with":      {},
+	"union":     {},
+	"intersect": {},
+	"except":    {},
+}
+
+// words which may follow a table name in a from clause, and so cannot be a table alias
+var nonAliasKeywords = map[string]struct{}{
+	"where":     {},
+	"join":      {},
+	"inner":     {},
+	"left":      {},
+	"right":     {},
+	"full":      {},
+	"cross":     {},
+	"natural":   {},
+	"on":        {},
+	"using":     {},
+	"order":     {},
+	"group":     {},
+	"having":    {},
+	"limit":     {},
+	"offset":    {},
+	"union":     {},
+	"intersect": {},
+	"except":    {},
+	"window":    {},
+	"fetch":     {},
+	"for":       {},
+	"returning": {},
+	"set":       {},
+}
+
+// statementTable is a table referenced in the from clause of a statement
+type statementTable struct {
+	schema string
+	name   string
+	alias  string
+}
+
+// qualifier returns the name used to qualify the columns of this table - the alias if there is one
+func (t statementTable) qualifier() string {
+	if t.alias != "" {
+		return t.alias
+	}
+	return t.name
+}
+
+// jsonKeyCompletion describes a jsonb key being completed, e.g. for t.tags->>'Na
+// the qualifier is 't', the column is 'tags' and the prefix is "t.tags->>'"
+type jsonKeyCompletion struct {
+	qualifier string
+	column    string
+	prefix    string
+}
+
+func tokeniseStatement(statement string) []string {
+	return statementTokenRegex.FindAllString(statement, -1)
+}
+
+// getStatementTables returns the tables referenced in the from and join clauses of the statement
+func getStatementTables(statement string) []statementTable {
+	var res []statementTable
+	tokens := tokeniseStatement(statement)
+	for i, token := range tokens {
+		keyword := strings.ToLower(token)
+		if keyword != "from" && keyword != "join" {
+			continue
+		}
+
+		for j := i + 1; j < len(tokens); {
+			if word := strings.ToLower(tokens[j]); word == "only" || word == "lateral" {
+				j++
+				continue
+			}
+			// stop at anything which is not a table name, e.g. a subquery
+			if !isIdentifier(tokens[j]) {
+				break
+			}
+			table := parseTableName(tokens[j])
+			j++
+			if j < len(tokens) && strings.EqualFold(tokens[j], "as") {
+				j++
+			}
+			if j < len(tokens) && isIdentifier(tokens[j]) && !strings.Contains(tokens[j], ".") {
+				if _, isKeyword := nonAliasKeywords[strings.ToLower(tokens[j])]; !isKeyword {
+					table.alias = normaliseIdentifier(tokens[j])
+					j++
+				}
+			}
+			res = append(res, table)
+
+			// a from clause may contain a comma separated list of tables
+			if keyword != "from" || j >= len(tokens) || tokens[j] != "," {
+				break
+			}
+			j++
+		}
+	}
+	return res
+}
+
+// isEditingColumn returns whether the text before the word being completed ends in a clause which references columns,
+// e.g. a select list or a where clause
+func isEditingColumn(text string) bool {
+	tokens := tokeniseStatement(text)
+	if len(tokens) > 0 && strings.EqualFold(tokens[len(tokens)-1], "as") {
+		// this is an alias
+		return false
+	}
+	for i := len(tokens) - 1; i >= 0; i-- {
+		token := strings.ToLower(tokens[i])
+		if _, ok := columnClauseKeywords[token]; ok {
+			return true
+		}
+		if _, ok := nonColumnClauseKeywords[token]; ok {
+			return false
+		}
+	}
+	return false
+}
+
+// getJsonKeyCompletion returns the jsonb key being completed by the given word, or nil if this is not a jsonb key lookup
+func getJsonKeyCompletion(word string) *jsonKeyCompletion {
+	match := jsonKeyRegex.FindStringSubmatch(word)
+	if match == nil {
+		return nil
+	}
+	return &jsonKeyCompletion{
+		qualifier: strings.ToLower(match[1]),
+		column:    strings.ToLower(match[2]),
+		prefix:    strings.TrimSuffix(word, match[3]),
+	}
+}
+
+func parseTableName(token string) statementTable {
+	parts := splitQualifiedName(token)
+	table := statementTable{name: normaliseIdentifier(parts[len(parts)-1])}
+	if len(parts) > 1 {
+		table.schema = normaliseIdentifier(parts[len(parts)-2])
+	}
+	return table
+}
+
+// splitQualifiedName splits a qualified name on the dots which are not inside quotes
+func splitQualifiedName(name string) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i, r := range name {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+		case r == '.' && !inQuotes:
+			parts = append(parts, name[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, name[start:])
+}
+
+// normaliseIdentifier converts an identifier into the name Postgres would use
+// - quoted identifiers are unquoted, unquoted identifiers are converted to lower case
+func normaliseIdentifier(identifier string) string {
+	if len(identifier) > 1 && strings.HasPrefix(identifier, `"`) && strings.HasSuffix(identifier, `"`) {
+		return strings.ReplaceAll(identifier[1:len(identifier)-1], `""`, `"`)
+	}
+	return strings.ToLower(identifier)
+}
+
+func isIdentifier(token string) bool {
+	if token == "" {
+		return false
+	}
+	c := token[0]
+	return c == '"' || c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
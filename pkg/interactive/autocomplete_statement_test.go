@@ -0,0 +1,69 @@
+package interactive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetStatementTables(t *testing.T) {
+	cases := map[string][]statementTable{
+		`select `:                     nil,
+		`select * from aws_s3_bucket`: {{name: "aws_s3_bucket"}},
+		`select * from aws.aws_s3_bucket as b where b.`:      {{schema: "aws", name: "aws_s3_bucket", alias: "b"}},
+		`SELECT * FROM AWS_S3_Bucket B WHERE`:                {{name: "aws_s3_bucket", alias: "b"}},
+		`select * from a, b.c x join d on x.id = d.id`:       {{name: "a"}, {schema: "b", name: "c", alias: "x"}, {name: "d"}},
+		`select * from a left join "My Schema"."T.1" t on`:   {{name: "a"}, {schema: "My Schema", name: "T.1", alias: "t"}},
+		`select * from (select id from a) s`:                 {{name: "a"}},
+		`select * from a, lateral jsonb_each(a.tags) as e`:   {{name: "a"}, {name: "jsonb_each"}},
+		`select * from only a where name = 'from x'`:         {{name: "a"}},
+		`select count(*) from a group by name order by name`: {{name: "a"}},
+	}
+
+	for input, expected := range cases {
+		actual := getStatementTables(input)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %v, got %v", input, expected, actual)
+		}
+	}
+}
+
+func TestIsEditingColumn(t *testing.T) {
+	cases := map[string]bool{
+		`select `:                                  true,
+		`select name, `:                            true,
+		`select name as `:                          false,
+		`select name from `:                        false,
+		`select name from a `:                      false,
+		`select name from a where `:                true,
+		`select name from a where x = 1 and `:      true,
+		`select * from a join b on `:               true,
+		`select * from a order by `:                true,
+		`select * from a limit `:                   false,
+		`select * from a where name = 'select' `:   true,
+		`select * from a where name = 'from' and `: true,
+	}
+
+	for input, expected := range cases {
+		if actual := isEditingColumn(input); actual != expected {
+			t.Errorf("%s: expected %v, got %v", input, expected, actual)
+		}
+	}
+}
+
+func TestGetJsonKeyCompletion(t *testing.T) {
+	cases := map[string]*jsonKeyCompletion{
+		`tags->>'`:     {column: "tags", prefix: `tags->>'`},
+		`tags->'Na`:    {column: "tags", prefix: `tags->'`},
+		`B.Tags->>'Na`: {qualifier: "b", column: "tags", prefix: `B.Tags->>'`},
+		`tags`:         nil,
+		`tags->>'x'`:   nil,
+		`'tags->>'`:    nil,
+	}
+
+	for input, expected := range cases {
+		actual := getJsonKeyCompletion(input)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: expected %v, got %v", input, expected, actual)
+		}
+	}
+}
@@ -28,6 +28,8 @@ import (
 	"github.com/turbot/steampipe/pkg/interactive/metaquery"
 	"github.com/turbot/steampipe/pkg/query"
 	"github.com/turbot/steampipe/pkg/query/queryhistory"
+	"github.com/turbot/steampipe/pkg/query/queryparams"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
 	"github.com/turbot/steampipe/pkg/statushooks"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
@@ -49,7 +51,15 @@ type InteractiveClient struct {
 	interactiveBuffer       []string
 	interactivePrompt       *prompt.Prompt
 	interactiveQueryHistory *queryhistory.QueryHistory
-	autocompleteOnEmpty     bool
+	// the client side query params, set using .set and referenced in queries as :name
+	queryParams *queryparams.Params
+	// the history entry for the query being executed - may be nil
+	historyEntry *queryhistory.HistoryEntry
+	// the last query executed - may be nil
+	lastQuery *modconfig.ResolvedQuery
+	// the most recent query results, most recent first
+	resultCache         []*queryresult.BufferedResult
+	autocompleteOnEmpty bool
 	// the cancellation function for the active query - may be nil
 	// NOTE: should ONLY be called by cancelActiveQueryIfAny
 	cancelActiveQuery context.CancelFunc
@@ -69,6 +79,8 @@ type InteractiveClient struct {
 	hidePrompt bool
 
 	suggestions *autoCompleteSuggestions
+	// the sampled keys of jsonb columns, used by full auto-completion - created when first needed
+	jsonKeys *jsonKeySampler
 }
 
 func getHighlighter(theme string) *Highlighter {
@@ -80,7 +92,12 @@ func getHighlighter(theme string) *Highlighter {
 }
 
 func newInteractiveClient(ctx context.Context, initData *query.InitData, result *RunInteractivePromptResult) (*InteractiveClient, error) {
-	interactiveQueryHistory, err := queryhistory.New()
+	interactiveQueryHistory, err := queryhistory.New(viper.GetString(constants.ArgModLocation))
+	if err != nil {
+		return nil, err
+	}
+	// initialise the query params with any passed using the --param flag
+	queryParams, err := queryparams.ParseArgs(viper.GetStringSlice(constants.ArgParam))
 	if err != nil {
 		return nil, err
 	}
@@ -88,6 +105,7 @@ func newInteractiveClient(ctx context.Context, initData *query.InitData, result
 		initData:                initData,
 		promptResult:            result,
 		interactiveQueryHistory: interactiveQueryHistory,
+		queryParams:             queryParams,
 		interactiveBuffer:       []string{},
 		autocompleteOnEmpty:     false,
 		initResultChan:          make(chan *db_common.InitResult, 1),
@@ -238,6 +256,7 @@ func (c *InteractiveClient) runInteractivePrompt(ctx context.Context) {
 		prompt.OptionInputTextColor(prompt.DefaultColor),
 		prompt.OptionPrefixTextColor(prompt.DefaultColor),
 		prompt.OptionMaxSuggestion(20),
+		prompt.OptionCompletionWordSeparator(completionWordSeparator),
 		// Known Key Bindings
 		prompt.OptionAddKeyBind(prompt.KeyBind{
 			Key: prompt.ControlC,
@@ -392,6 +411,7 @@ func (c *InteractiveClient) executeQuery(ctx context.Context, queryCtx context.C
 		}
 	}
 
+	c.lastQuery = resolvedQuery
 	t := time.Now()
 	result, err := c.client().Execute(queryCtx, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
 	if err != nil {
@@ -400,9 +420,91 @@ func (c *InteractiveClient) executeQuery(ctx context.Context, queryCtx context.C
 		if cmdconfig.Viper().GetString(constants.ArgTiming) != constants.ArgOff {
 			display.DisplayErrorTiming(t)
 		}
+		c.setHistoryResult(time.Since(t), 0, err)
 	} else {
+		// wrap the result so we can record the row count in the history
+		result, summary := result.WithSummary()
+		// and record the rows, so the result can be re-displayed
+		result, recording := result.Record(constants.MaxCachedResultRows)
+		if outputFile := c.outputFile(); outputFile != "" {
+			c.writeResultToFile(ctx, result, summary, outputFile)
+		} else {
+			// StreamResult returns once the result has been displayed
+			c.promptResult.Streamer.StreamResult(result)
+		}
+		c.setHistoryResult(time.Since(t), summary.RowCount, summary.Error)
+		c.cacheResult(recording)
+	}
+}
+
+// cacheResult adds the recorded result to the result cache, if all rows were recorded
+func (c *InteractiveClient) cacheResult(recording *queryresult.ResultRecording) {
+	if !recording.Complete {
+		return
+	}
+	c.resultCache = append([]*queryresult.BufferedResult{recording.Result}, c.resultCache...)
+	if len(c.resultCache) > constants.ResultCacheSize {
+		c.resultCache = c.resultCache[:constants.ResultCacheSize]
+	}
+}
+
+// outputFile returns the file the next query result should be written to (set using .once or .out)
+// or an empty string if the result should be displayed
+func (c *InteractiveClient) outputFile() string {
+	if onceFile := cmdconfig.Viper().GetString(constants.ConfigKeyOnceOutputFile); onceFile != "" {
+		// the once file is only used for a single query
+		cmdconfig.Viper().Set(constants.ConfigKeyOnceOutputFile, "")
+		return onceFile
+	}
+	return cmdconfig.Viper().GetString(constants.ConfigKeyOutputFile)
+}
+
+// writeResultToFile appends the result to the output file, in the current output format
+// if the file cannot be opened, the result is displayed
+func (c *InteractiveClient) writeResultToFile(ctx context.Context, result *queryresult.Result, summary *queryresult.ResultSummary, path string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		error_helpers.ShowErrorWithMessage(ctx, err, "failed to open output file - displaying result")
 		c.promptResult.Streamer.StreamResult(result)
+		return
 	}
+	defer f.Close()
+
+	// hide the spinner so it does not interfere with the timing output
+	statushooks.Done(ctx)
+	display.ShowOutput(ctx, result, display.WithOutputWriter(f))
+	if summary.Error == nil {
+		fmt.Printf("Wrote %d %s to %s\n", summary.RowCount, utils.Pluralize("row", summary.RowCount), path)
+	}
+}
+
+// resolveAndExecuteQuery resolves the query string using the workspace and executes it
+func (c *InteractiveClient) resolveAndExecuteQuery(ctx context.Context, queryString string) error {
+	resolvedQuery, err := c.resolveQuery(queryString)
+	if err != nil {
+		return err
+	}
+	c.executeQuery(ctx, ctx, resolvedQuery)
+	return nil
+}
+
+// resolveQuery resolves the query string using the workspace, and substitutes any query params
+func (c *InteractiveClient) resolveQuery(queryString string) (*modconfig.ResolvedQuery, error) {
+	resolvedQuery, _, err := c.workspace().ResolveQueryAndArgsFromSQLString(queryString)
+	if err != nil {
+		return nil, err
+	}
+	resolvedQuery.ExecuteSQL, resolvedQuery.Args = c.queryParams.Substitute(resolvedQuery.ExecuteSQL, resolvedQuery.Args)
+	return resolvedQuery, nil
+}
+
+// setHistoryResult records the execution metadata of the current query in the history entry
+func (c *InteractiveClient) setHistoryResult(duration time.Duration, rowCount int, err error) {
+	if c.historyEntry == nil {
+		return
+	}
+	c.historyEntry.SetResult(duration, rowCount, err)
+	c.historyEntry.SearchPath = c.client().GetRequiredSessionSearchPath()
 }
 
 func (c *InteractiveClient) getQuery(ctx context.Context, line string) *modconfig.ResolvedQuery {
@@ -414,11 +516,9 @@ func (c *InteractiveClient) getQuery(ctx context.Context, line string) *modconfi
 	// store the history (the raw line which was entered)
 	historyEntry := line
 	defer func() {
-		if len(historyEntry) > 0 {
-			// we want to store even if we fail to resolve a query
-			c.interactiveQueryHistory.Push(historyEntry)
-		}
-
+		// we want to store even if we fail to resolve a query
+		// (Push will not store an empty entry)
+		c.historyEntry = c.interactiveQueryHistory.Push(historyEntry)
 	}()
 
 	// wait for initialisation to complete so we can access the workspace
@@ -503,6 +603,9 @@ func (c *InteractiveClient) getQuery(ctx context.Context, line string) *modconfi
 	if !isNamedQuery && len(strings.Split(resolvedQuery.ExecuteSQL, "\n")) > 1 {
 		historyEntry = resolvedQuery.ExecuteSQL
 	}
+	// replace any query param references with bind parameters
+	// (the history entry retains the references)
+	resolvedQuery.ExecuteSQL, resolvedQuery.Args = c.queryParams.Substitute(resolvedQuery.ExecuteSQL, resolvedQuery.Args)
 
 	return resolvedQuery
 }
@@ -527,11 +630,22 @@ func (c *InteractiveClient) executeMetaquery(ctx context.Context, query string)
 
 	// validation passed, now we will run
 	return metaquery.Handle(ctx, &metaquery.HandlerInput{
-		Query:                 query,
-		Client:                client,
-		Schema:                c.schemaMetadata,
-		SearchPath:            client.GetRequiredSessionSearchPath(),
-		Prompt:                c.interactivePrompt,
+		Query:        query,
+		Client:       client,
+		Schema:       c.schemaMetadata,
+		SearchPath:   client.GetRequiredSessionSearchPath(),
+		Prompt:       c.interactivePrompt,
+		History:      c.interactiveQueryHistory,
+		Params:       c.queryParams,
+		Workspace:    c.workspace(),
+		LastQuery:    c.lastQuery,
+		Results:      c.resultCache,
+		ExecuteQuery: c.resolveAndExecuteQuery,
+		ResolveQuery: c.resolveQuery,
+		OnWorkspaceChanged: func(ctx context.Context) {
+			// refresh the suggestions to include any new queries
+			c.initialiseSuggestions(ctx)
+		},
 		ClosePrompt:           func() { c.afterClose = AfterPromptCloseExit },
 		GetConnectionStateMap: c.getConnectionState,
 	})
@@ -596,7 +710,8 @@ func (c *InteractiveClient) queryCompleter(d prompt.Document) []prompt.Suggest {
 	var s []prompt.Suggest
 
 	switch {
-	case isFirstWord(text):
+	// continuation lines of a multi-line statement never start with the first word
+	case isFirstWord(text) && len(c.interactiveBuffer) == 0:
 		suggestions := c.getFirstWordSuggestions(text)
 		s = append(s, suggestions...)
 	case metaquery.IsMetaQuery(text):
@@ -609,10 +724,12 @@ func (c *InteractiveClient) queryCompleter(d prompt.Document) []prompt.Suggest {
 		if queryInfo := getQueryInfo(text); queryInfo.EditingTable {
 			tableSuggestions := c.getTableAndConnectionSuggestions(lastWord(text))
 			s = append(s, tableSuggestions...)
+		} else if autoCompleteMode() != constants.ArgBasic {
+			s = append(s, c.getSchemaSuggestions(d)...)
 		}
 	}
 
-	return prompt.FilterHasPrefix(s, d.GetWordBeforeCursor(), true)
+	return prompt.FilterHasPrefix(s, d.GetWordBeforeCursorUntilSeparator(completionWordSeparator), true)
 }
 
 func (c *InteractiveClient) getFirstWordSuggestions(word string) []prompt.Suggest {
@@ -724,8 +841,8 @@ func (c *InteractiveClient) handlePostgresNotification(ctx context.Context, noti
 
 func (c *InteractiveClient) handleErrorsAndWarningsNotification(ctx context.Context, notification *steampipeconfig.ErrorsAndWarningsNotification) {
 	log.Printf("[TRACE] handleErrorsAndWarningsNotification")
-	output := viper.Get(constants.ArgOutput)
-	if output == constants.OutputFormatJSON || output == constants.OutputFormatCSV {
+	output := viper.GetString(constants.ArgOutput)
+	if helpers.StringSliceContains(constants.MachineReadableOutputFormats, output) {
 		return
 	}
 
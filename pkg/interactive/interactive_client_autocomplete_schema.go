@@ -0,0 +1,157 @@
+package interactive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/c-bata/go-prompt"
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// completionTable is a table referenced by the statement being edited, resolved using the schema metadata
+type completionTable struct {
+	// the name used to qualify columns of the table - the alias if there is one
+	qualifier string
+	schema    db_common.TableSchema
+}
+
+// autoCompleteMode returns the auto-complete mode set using .autocomplete - on, basic or full
+func autoCompleteMode() string {
+	return cmdconfig.Viper().GetString(constants.ConfigKeyAutoCompleteMode)
+}
+
+// getSchemaSuggestions returns column, function and (in full mode) jsonb key suggestions for the statement being edited
+func (c *InteractiveClient) getSchemaSuggestions(d prompt.Document) []prompt.Suggest {
+	if c.schemaMetadata == nil {
+		return nil
+	}
+
+	statement, textBeforeWord, word := c.getCurrentStatement(d)
+	tables := c.resolveStatementTables(getStatementTables(statement))
+
+	if jsonKey := getJsonKeyCompletion(word); jsonKey != nil {
+		if autoCompleteMode() != constants.ArgFull {
+			return nil
+		}
+		return c.getJsonKeySuggestions(tables, jsonKey)
+	}
+	if !isEditingColumn(textBeforeWord) {
+		return nil
+	}
+
+	s := getColumnSuggestions(tables, word)
+	if !strings.Contains(word, ".") {
+		s = append(s, postgresFunctionSuggestions...)
+	}
+	return s
+}
+
+// getCurrentStatement returns the full text of the statement being edited (including any lines already entered
+// in multi-line mode), the text of the statement before the word being completed, and the word being completed
+func (c *InteractiveClient) getCurrentStatement(d prompt.Document) (statement, textBeforeWord, word string) {
+	word = d.GetWordBeforeCursorUntilSeparator(completionWordSeparator)
+
+	previousLines := strings.Join(c.interactiveBuffer, "\n")
+	before := previousLines + "\n" + d.TextBeforeCursor()
+	after := d.TextAfterCursor()
+	// only consider the statement containing the cursor
+	if idx := strings.LastIndex(before, ";"); idx != -1 {
+		before = before[idx+1:]
+	}
+	if idx := strings.Index(after, ";"); idx != -1 {
+		after = after[:idx]
+	}
+
+	return before + after, strings.TrimSuffix(before, word), word
+}
+
+// resolveStatementTables finds the schema of each table referenced by the statement
+// unqualified tables are resolved using the search path
+func (c *InteractiveClient) resolveStatementTables(tables []statementTable) []completionTable {
+	var res []completionTable
+	for _, table := range tables {
+		schemas := []string{table.schema}
+		if table.schema == "" {
+			schemas = append([]string{c.schemaMetadata.TemporarySchemaName}, c.client().GetRequiredSessionSearchPath()...)
+		}
+		for _, schemaName := range schemas {
+			if tableSchema, ok := c.schemaMetadata.Schemas[strings.Trim(schemaName, `"`)][table.name]; ok {
+				res = append(res, completionTable{qualifier: table.qualifier(), schema: tableSchema})
+				break
+			}
+		}
+	}
+	return res
+}
+
+// getColumnSuggestions returns suggestions for the columns of the given tables
+// if the word being completed is qualified, only the columns of the table with that name or alias are suggested
+func getColumnSuggestions(tables []completionTable, word string) []prompt.Suggest {
+	qualifier := ""
+	if idx := strings.LastIndex(word, "."); idx != -1 {
+		qualifier = normaliseIdentifier(word[:idx])
+	}
+
+	var s []prompt.Suggest
+	added := make(map[string]struct{})
+	for _, table := range tables {
+		if qualifier != "" && qualifier != table.qualifier {
+			continue
+		}
+		var tableSuggestions []prompt.Suggest
+		for _, column := range table.schema.Columns {
+			text := sanitiseColumnName(column.Name)
+			if qualifier != "" {
+				text = fmt.Sprintf("%s.%s", word[:strings.LastIndex(word, ".")], text)
+			}
+			// if several tables have a column with the same name, just suggest it once
+			if _, alreadyAdded := added[text]; alreadyAdded {
+				continue
+			}
+			added[text] = struct{}{}
+			description := fmt.Sprintf("Column: %s (%s)", column.Type, table.schema.Name)
+			tableSuggestions = append(tableSuggestions, prompt.Suggest{Text: text, Output: text, Description: description})
+		}
+		sort.Slice(tableSuggestions, func(i, j int) bool {
+			return tableSuggestions[i].Text < tableSuggestions[j].Text
+		})
+		s = append(s, tableSuggestions...)
+	}
+	return s
+}
+
+// getJsonKeySuggestions returns suggestions for the keys of a jsonb column, sampled from the table data
+func (c *InteractiveClient) getJsonKeySuggestions(tables []completionTable, jsonKey *jsonKeyCompletion) []prompt.Suggest {
+	for _, table := range tables {
+		if jsonKey.qualifier != "" && jsonKey.qualifier != table.qualifier {
+			continue
+		}
+		column, ok := table.schema.Columns[jsonKey.column]
+		if !ok || column.Type != "jsonb" {
+			continue
+		}
+
+		if c.jsonKeys == nil {
+			c.jsonKeys = newJsonKeySampler(c.client())
+		}
+		var s []prompt.Suggest
+		for _, key := range c.jsonKeys.getKeys(table.schema, column.Name) {
+			text := fmt.Sprintf("%s%s'", jsonKey.prefix, strings.ReplaceAll(key, "'", "''"))
+			s = append(s, prompt.Suggest{Text: text, Output: text, Description: fmt.Sprintf("Key of %s (%s)", column.Name, table.schema.Name)})
+		}
+		return s
+	}
+	return nil
+}
+
+// sanitiseColumnName escapes a column name if it contains spaces, special characters or upper case characters
+func sanitiseColumnName(name string) string {
+	if strings.ContainsAny(name, " -.") || utils.ContainsUpper(name) {
+		return db_common.PgEscapeName(name)
+	}
+	return name
+}
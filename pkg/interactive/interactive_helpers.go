@@ -22,7 +22,7 @@ func getQueryInfo(text string) *queryCompletionInfo {
 }
 
 func isEditingTable(prevWord string) bool {
-	var editingTable = prevWord == "from"
+	var editingTable = prevWord == "from" || prevWord == "join"
 	return editingTable
 }
 
@@ -0,0 +1,93 @@
+package interactive
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+const (
+	// the number of rows read to sample the keys of a jsonb column
+	jsonKeySampleRows = 20
+	// the maximum number of keys suggested for a jsonb column
+	maxJsonKeys          = 100
+	jsonKeySampleTimeout = 30 * time.Second
+)
+
+// jsonKeySampler samples the keys of jsonb columns for auto-completion
+// keys are sampled in the background the first time a column is completed, so that typing is not blocked
+// while the table is queried, and are cached for the rest of the session
+type jsonKeySampler struct {
+	client db_common.Client
+	// map of qualified column name to sampled keys
+	// an entry is added (with nil keys) as soon as sampling starts, so each column is only sampled once
+	keys map[string][]string
+	mut  sync.Mutex
+}
+
+func newJsonKeySampler(client db_common.Client) *jsonKeySampler {
+	return &jsonKeySampler{
+		client: client,
+		keys:   make(map[string][]string),
+	}
+}
+
+// getKeys returns the sampled keys of the given column, starting sampling if the column has not been sampled yet
+func (s *jsonKeySampler) getKeys(table db_common.TableSchema, column string) []string {
+	name := fmt.Sprintf("%s.%s.%s", db_common.PgEscapeName(table.Schema), db_common.PgEscapeName(table.Name), db_common.PgEscapeName(column))
+
+	s.mut.Lock()
+	defer s.mut.Unlock()
+
+	keys, sampled := s.keys[name]
+	if !sampled {
+		s.keys[name] = nil
+		go s.sample(name, table, column)
+	}
+	return keys
+}
+
+func (s *jsonKeySampler) sample(name string, table db_common.TableSchema, column string) {
+	ctx, cancel := context.WithTimeout(context.Background(), jsonKeySampleTimeout)
+	defer cancel()
+
+	query := jsonKeySampleQuery(table, column)
+	result, err := s.client.ExecuteSync(ctx, query)
+	if err != nil {
+		// leave the keys empty so we do not sample this column again
+		log.Printf("[TRACE] failed to sample keys of %s: %s", name, err.Error())
+		return
+	}
+
+	var keys []string
+	for _, r := range result.Rows {
+		row, ok := r.(*queryresult.RowResult)
+		if !ok || len(row.Data) == 0 {
+			continue
+		}
+		if key, ok := row.Data[0].(string); ok {
+			keys = append(keys, key)
+		}
+	}
+
+	s.mut.Lock()
+	s.keys[name] = keys
+	s.mut.Unlock()
+}
+
+// jsonKeySampleQuery returns the query which samples the keys of a jsonb column
+// the inner query must have no quals, so that the limit is pushed down to the plugin - otherwise the whole table
+// would be listed (and hydrated) to sample it - non object values are filtered out of the sampled rows instead
+func jsonKeySampleQuery(table db_common.TableSchema, column string) string {
+	return fmt.Sprintf(`select distinct jsonb_object_keys(c) as k from (select %s as c from %s.%s limit %d) as sample where jsonb_typeof(c) = 'object' order by k limit %d`,
+		db_common.PgEscapeName(column),
+		db_common.PgEscapeName(table.Schema),
+		db_common.PgEscapeName(table.Name),
+		jsonKeySampleRows,
+		maxJsonKeys)
+}
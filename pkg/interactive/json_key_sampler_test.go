@@ -0,0 +1,34 @@
+package interactive
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/db/db_common"
+)
+
+func TestJsonKeySampleQuery(t *testing.T) {
+	cases := map[string]struct {
+		table    db_common.TableSchema
+		column   string
+		expected string
+	}{
+		"column": {
+			table:    db_common.TableSchema{Schema: "aws", Name: "aws_s3_bucket"},
+			column:   "tags",
+			expected: `select distinct jsonb_object_keys(c) as k from (select "tags" as c from "aws"."aws_s3_bucket" limit 20) as sample where jsonb_typeof(c) = 'object' order by k limit 100`,
+		},
+		"quoted names": {
+			table:    db_common.TableSchema{Schema: "My Schema", Name: "T.1"},
+			column:   `a"b`,
+			expected: `select distinct jsonb_object_keys(c) as k from (select "a""b" as c from "My Schema"."T.1" limit 20) as sample where jsonb_typeof(c) = 'object' order by k limit 100`,
+		},
+	}
+
+	// the sampled rows must be selected with no quals, so the limit is pushed down to the plugin
+	for name, test := range cases {
+		actual := jsonKeySampleQuery(test.table, test.column)
+		if actual != test.expected {
+			t.Errorf("%s: expected %s, got %s", name, test.expected, actual)
+		}
+	}
+}
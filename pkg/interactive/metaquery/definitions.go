@@ -90,12 +90,16 @@ func init() {
 			title:       constants.CmdOutput,
 			handler:     setViperConfigFromArg(constants.ArgOutput),
 			validator:   composeValidator(exactlyNArgs(1), validatorFromArgsOf(constants.CmdOutput)),
-			description: "Set output format: csv, json, table or line",
+			description: "Set output format: csv, json, table, line, md, html, jsonl or yaml",
 			args: []metaQueryArg{
 				{value: constants.OutputFormatJSON, description: "Set output to JSON"},
 				{value: constants.OutputFormatCSV, description: "Set output to CSV"},
 				{value: constants.OutputFormatTable, description: "Set output to Table"},
 				{value: constants.OutputFormatLine, description: "Set output to Line"},
+				{value: constants.OutputFormatMD, description: "Set output to Markdown"},
+				{value: constants.OutputFormatHTML, description: "Set output to HTML"},
+				{value: constants.OutputFormatJSONL, description: "Set output to newline delimited JSON"},
+				{value: constants.OutputFormatYAML, description: "Set output to YAML"},
 			},
 			completer: completerFromArgsOf(constants.CmdOutput),
 		},
@@ -151,14 +155,99 @@ func init() {
 			validator:   exactlyNArgs(1),
 			description: "Set a prefix to the current search-path",
 		},
+		constants.CmdHistory: {
+			title:   constants.CmdHistory,
+			handler: showHistory,
+			// the search string may contain spaces, so accept any number of args
+			validator:   atLeastNArgs(0),
+			description: "List the query history, or search it by passing in a search string",
+		},
+		constants.CmdExplain: {
+			title:   constants.CmdExplain,
+			handler: explain,
+			// the query may contain any number of words, so just ensure a query has been provided
+			validator:   atLeastNArgs(1),
+			description: "Show the query plan - use 'analyze' to execute the query and show the plugin scans",
+			args: []metaQueryArg{
+				{value: constants.ArgAnalyze, description: "Execute the query and show the plugin scans"},
+			},
+			completer: completerFromArgsOf(constants.CmdExplain),
+		},
+		constants.CmdSave: {
+			title:       constants.CmdSave,
+			handler:     saveQuery,
+			validator:   exactlyNArgs(1),
+			description: "Save the last query to the workspace, to be run with .run",
+		},
+		constants.CmdSaved: {
+			title:       constants.CmdSaved,
+			handler:     listSavedQueries,
+			validator:   noArgs,
+			description: "List the saved queries",
+		},
+		constants.CmdRun: {
+			title:   constants.CmdRun,
+			handler: runSavedQuery,
+			// the query args may contain spaces, so just ensure a name has been provided
+			validator:   atLeastNArgs(1),
+			description: "Run a saved query, passing any args in parentheses, e.g. .run my_query(\"value\")",
+		},
+		constants.CmdSet: {
+			title:   constants.CmdSet,
+			handler: setParam,
+			// the value may contain spaces, so accept any number of args
+			validator:   atLeastNArgs(0),
+			description: "Set a query param, referenced in queries as :name, or list the params if no name is given",
+		},
+		constants.CmdUnset: {
+			title:       constants.CmdUnset,
+			handler:     unsetParam,
+			validator:   exactlyNArgs(1),
+			description: "Unset a query param",
+		},
+		constants.CmdOut: {
+			title:   constants.CmdOut,
+			handler: setOutputFile,
+			// the file path may contain spaces, so accept any number of args
+			validator:   atLeastNArgs(0),
+			description: "Write query results to a file, in the current output format - run with no file to display results again",
+		},
+		constants.CmdOnce: {
+			title:   constants.CmdOnce,
+			handler: setOnceOutputFile,
+			// the file path may contain spaces, so just ensure a path has been provided
+			validator:   atLeastNArgs(1),
+			description: "Write the result of the next query to a file, in the current output format",
+		},
+		constants.CmdLast: {
+			title:       constants.CmdLast,
+			handler:     showLastResult,
+			validator:   atLeastNArgs(0),
+			description: "Re-display a previous result without re-running the query: .last [n] [as {format}] [columns {column,...}]",
+		},
+		constants.CmdPage: {
+			title:       constants.CmdPage,
+			handler:     pageLastResult,
+			validator:   atLeastNArgs(0),
+			description: "Re-display a previous result in the pager: .page [n] [as {format}] [columns {column,...}]",
+		},
+		constants.CmdWatch: {
+			title:   constants.CmdWatch,
+			handler: watchQuery,
+			// the query may contain any number of words, so just ensure an interval has been provided
+			validator:   atLeastNArgs(1),
+			description: "Re-run a query every interval, highlighting changed rows, until Ctrl+C: .watch {interval} [query] - the last query is watched if none is given",
+		},
 		constants.CmdAutoComplete: {
 			title:       "auto-complete",
 			handler:     setAutoComplete,
-			validator:   booleanValidator(constants.CmdAutoComplete, validatorFromArgsOf(constants.CmdAutoComplete)),
-			description: "Enable or disable auto-completion",
+			validator:   composeValidator(atMostNArgs(1), validatorFromArgsOf(constants.CmdAutoComplete)),
+			description: "Enable or disable auto-completion, or set what is completed",
 			args: []metaQueryArg{
-				{value: constants.ArgOn, description: "Turn on auto-completion"},
+				{value: constants.ArgOn, description: "Complete tables, columns and functions"},
 				{value: constants.ArgOff, description: "Turn off auto-completion"},
+				{value: constants.ArgBasic, description: "Complete tables and connections only"},
+				{value: constants.ArgFull, description: "Also complete jsonb keys, sampled from the table data"},
 			},
 			completer: completerFromArgsOf(constants.CmdAutoComplete),
 		},
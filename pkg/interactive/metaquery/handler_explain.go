@@ -0,0 +1,37 @@
+package metaquery
+
+import (
+	"context"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/query/queryexplain"
+	"github.com/turbot/steampipe/pkg/statushooks"
+)
+
+// .explain [analyze] {query}
+// show the query plan, combined with the plugin scans if the query is analyzed
+func explain(ctx context.Context, input *HandlerInput) error {
+	// take the query from the raw metaquery, as the argument parsing does not preserve the query text
+	query := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input.Query), constants.CmdExplain))
+	analyze := false
+	if fields := strings.Fields(query); len(fields) > 1 && strings.EqualFold(fields[0], constants.ArgAnalyze) {
+		analyze = true
+		query = strings.TrimSpace(query[len(fields[0]):])
+	}
+
+	// replace any query param references with bind parameters
+	query, args := input.Params.Substitute(query, nil)
+
+	statushooks.Show(ctx)
+	statushooks.SetStatus(ctx, "Explaining query…")
+	explanation, err := queryexplain.Explain(ctx, input.Client, analyze, query, args...)
+	statushooks.Done(ctx)
+	if err != nil {
+		return err
+	}
+
+	display.ShowExplanation(explanation)
+	return nil
+}
@@ -0,0 +1,37 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+)
+
+// .history
+// list the query history, optionally filtered by a search string
+func showHistory(_ context.Context, input *HandlerInput) error {
+	if input.History == nil {
+		return fmt.Errorf("query history is not available")
+	}
+	search := strings.Join(input.args(), " ")
+	entries := input.History.Search(search)
+	if len(entries) == 0 {
+		if search == "" {
+			fmt.Println("No query history.")
+		} else {
+			fmt.Printf("No queries matching '%s' found in the history.\n", search)
+		}
+		return nil
+	}
+
+	headers, rows := queryhistory.TableRows(entries)
+	display.ShowWrappedTable(headers, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
+
+	fmt.Printf(`
+To re-run a query from the history, run %s
+`, constants.Bold("steampipe query history --run {id}"))
+	return nil
+}
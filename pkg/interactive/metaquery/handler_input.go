@@ -5,7 +5,12 @@ import (
 
 	"github.com/c-bata/go-prompt"
 	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/query/queryhistory"
+	"github.com/turbot/steampipe/pkg/query/queryparams"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/workspace"
 )
 
 type ConnectionStateGetter func(context.Context) (steampipeconfig.ConnectionStateMap, error)
@@ -20,6 +25,20 @@ type HandlerInput struct {
 	Query                 string
 	GetConnectionStateMap ConnectionStateGetter
 	SearchPath            []string
+	History               *queryhistory.QueryHistory
+	Params                *queryparams.Params
+	Workspace             *workspace.Workspace
+	// the last query executed in the session - may be nil
+	// ExecuteSQL has any query param references substituted, RawSQL is the sql as entered
+	LastQuery *modconfig.ResolvedQuery
+	// the most recent query results, most recent first
+	Results []*queryresult.BufferedResult
+	// ExecuteQuery resolves and executes a query string, displaying the result
+	ExecuteQuery func(context.Context, string) error
+	// ResolveQuery resolves a query string, without executing it
+	ResolveQuery func(string) (*modconfig.ResolvedQuery, error)
+	// OnWorkspaceChanged is called after the workspace has been modified (e.g. a query has been saved)
+	OnWorkspaceChanged func(context.Context)
 }
 
 func (h *HandlerInput) args() []string {
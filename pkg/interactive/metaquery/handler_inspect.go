@@ -11,6 +11,7 @@ import (
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
 	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/db/db_common"
 	"github.com/turbot/steampipe/pkg/display"
 	"github.com/turbot/steampipe/pkg/error_helpers"
 	"github.com/turbot/steampipe/pkg/steampipeconfig"
@@ -234,8 +235,25 @@ func inspectQualifiedTable(ctx context.Context, connectionName string, tableName
 		return fmt.Errorf("could not find table '%s' in '%s'", tableName, connectionName)
 	}
 
+	// load the key columns and hydrate functions of the plugin columns (if this is a plugin table)
+	pluginColumns := loadPluginColumns(ctx, input, connectionStateMap[connectionName], tableName)
+	if len(pluginColumns) > 0 {
+		header = []string{"column", "type", "key column", "hydrate", "description"}
+	}
+
 	for _, columnSchema := range tableSchema.Columns {
-		rows = append(rows, []string{columnSchema.Name, columnSchema.Type, columnSchema.Description})
+		if len(pluginColumns) == 0 {
+			rows = append(rows, []string{columnSchema.Name, columnSchema.Type, columnSchema.Description})
+			continue
+		}
+		var keyColumn, hydrate string
+		if pluginColumn := pluginColumns[columnSchema.Name]; pluginColumn != nil {
+			keyColumn = keyColumnString(pluginColumn)
+			if pluginColumn.HydrateName != nil {
+				hydrate = *pluginColumn.HydrateName
+			}
+		}
+		rows = append(rows, []string{columnSchema.Name, columnSchema.Type, keyColumn, hydrate, columnSchema.Description})
 	}
 
 	// sort by column name
@@ -245,9 +263,80 @@ func inspectQualifiedTable(ctx context.Context, connectionName string, tableName
 
 	display.ShowWrappedTable(header, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
 
+	if len(pluginColumns) > 0 {
+		fmt.Printf(`
+Quals on key columns are passed to the plugin, limiting the data fetched - %s key columns must be specified in the where clause.
+Columns with a hydrate function make an additional API call for each row - only select them if needed.
+
+`, constants.Bold("required"))
+	}
 	return nil
 }
 
+// loadPluginColumns loads the plugin column details of the table
+// if the connection is not a plugin connection, or the details cannot be loaded, nil is returned
+func loadPluginColumns(ctx context.Context, input *HandlerInput, connectionState *steampipeconfig.ConnectionState, tableName string) map[string]*db_common.PluginColumn {
+	if connectionState == nil || input.Client == nil {
+		return nil
+	}
+	conn, err := input.Client.AcquireManagementConnection(ctx)
+	if err != nil {
+		log.Printf("[WARN] failed to acquire connection to load plugin columns: %s", err.Error())
+		return nil
+	}
+	defer conn.Release()
+
+	pluginColumns, err := db_common.LoadPluginColumns(ctx, conn.Conn(), connectionState.Plugin, tableName)
+	if err != nil {
+		log.Printf("[WARN] failed to load plugin columns for %s.%s: %s", connectionState.ConnectionName, tableName, err.Error())
+		return nil
+	}
+	return pluginColumns
+}
+
+// keyColumnString returns a description of the list and get key column config of the column, e.g.
+// list (required): =
+// get (optional): =, like
+func keyColumnString(pluginColumn *db_common.PluginColumn) string {
+	var lines []string
+	for _, c := range []struct {
+		name   string
+		config *db_common.KeyColumnConfig
+	}{{"list", pluginColumn.ListConfig}, {"get", pluginColumn.GetConfig}} {
+		if c.config == nil {
+			continue
+		}
+		require := strings.ReplaceAll(c.config.Require, "_", " ")
+		if require == "" {
+			require = "required"
+		}
+		lines = append(lines, fmt.Sprintf("%s (%s): %s", c.name, require, strings.Join(displayOperators(c.config.Operators), ", ")))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// displayOperators converts the operators as stored in the plugin column table back to their sql form
+func displayOperators(operators []string) []string {
+	if len(operators) == 0 {
+		return []string{"="}
+	}
+	res := make([]string, len(operators))
+	for i, operator := range operators {
+		switch operator {
+		case "gt":
+			operator = ">"
+		case "lt":
+			operator = "<"
+		case "ge":
+			operator = ">="
+		case "le":
+			operator = "<="
+		}
+		res[i] = operator
+	}
+	return res
+}
+
 // inspect the connection with the given name
 // return whether connectionName was identified as an existing connection
 func inspectConnection(ctx context.Context, connectionName string, input *HandlerInput) bool {
@@ -0,0 +1,37 @@
+package metaquery
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/db/db_common"
+)
+
+func TestKeyColumnString(t *testing.T) {
+	cases := map[string]struct {
+		column   *db_common.PluginColumn
+		expected string
+	}{
+		"not a key column": {
+			column:   &db_common.PluginColumn{Name: "arn"},
+			expected: "",
+		},
+		"required get": {
+			column:   &db_common.PluginColumn{Name: "name", GetConfig: &db_common.KeyColumnConfig{Operators: []string{"="}, Require: "required"}},
+			expected: "get (required): =",
+		},
+		"list and get": {
+			column: &db_common.PluginColumn{
+				Name:       "region",
+				ListConfig: &db_common.KeyColumnConfig{Operators: []string{"=", "!=", "ge", "lt"}, Require: "any_of"},
+				GetConfig:  &db_common.KeyColumnConfig{Require: "optional"},
+			},
+			expected: "list (any of): =, !=, >=, <\nget (optional): =",
+		},
+	}
+
+	for name, test := range cases {
+		if actual := keyColumnString(test.column); actual != test.expected {
+			t.Errorf("%s: expected '%s', got '%s'", name, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+const (
+	redisplayArgAs      = "as"
+	redisplayArgColumns = "columns"
+)
+
+// redisplayOptions are the options parsed from the args of .last and .page
+type redisplayOptions struct {
+	// the index of the result, where 1 is the most recent
+	index        int
+	outputFormat string
+	columns      []string
+}
+
+// .last [n] [as {format}] [columns {column,...}]
+// re-display a previous query result, without re-executing the query
+func showLastResult(ctx context.Context, input *HandlerInput) error {
+	return redisplayResult(ctx, input, false)
+}
+
+// .page [n] [as {format}] [columns {column,...}]
+// re-display a previous query result in the pager, without re-executing the query
+func pageLastResult(ctx context.Context, input *HandlerInput) error {
+	return redisplayResult(ctx, input, true)
+}
+
+func redisplayResult(ctx context.Context, input *HandlerInput, page bool) error {
+	opts, err := parseRedisplayArgs(input.args())
+	if err != nil {
+		return err
+	}
+	if len(input.Results) == 0 {
+		return fmt.Errorf("there are no query results to display - results with more than %d rows are not kept", constants.MaxCachedResultRows)
+	}
+	if opts.index > len(input.Results) {
+		count := len(input.Results)
+		return fmt.Errorf("result %d is not available - there %s %d %s", opts.index, utils.Pluralize("is", count), count, utils.Pluralize("result", count))
+	}
+
+	result := input.Results[opts.index-1]
+	if len(opts.columns) > 0 {
+		if result, err = result.SelectColumns(opts.columns); err != nil {
+			return err
+		}
+	}
+
+	// the result has already been displayed, so do not show the timing again
+	displayOpts := []display.DisplayOption{display.WithTimingDisabled()}
+	if opts.outputFormat != "" {
+		displayOpts = append(displayOpts, display.WithOutputFormat(opts.outputFormat))
+	}
+	if page {
+		displayOpts = append(displayOpts, display.WithPager())
+	}
+	display.ShowOutput(ctx, result.NewResult(), displayOpts...)
+	return nil
+}
+
+func parseRedisplayArgs(args []string) (*redisplayOptions, error) {
+	opts := &redisplayOptions{index: 1}
+	if len(args) > 0 {
+		if index, err := strconv.Atoi(args[0]); err == nil {
+			if index < 1 {
+				return nil, fmt.Errorf("result number must be 1 or more")
+			}
+			opts.index = index
+			args = args[1:]
+		}
+	}
+
+	for len(args) > 0 {
+		switch strings.ToLower(args[0]) {
+		case redisplayArgAs:
+			if len(args) < 2 {
+				return nil, fmt.Errorf("'%s' must be followed by an output format", redisplayArgAs)
+			}
+			outputFormat := strings.ToLower(args[1])
+			if !isValidOutputFormat(outputFormat) {
+				return nil, fmt.Errorf("invalid output format '%s'", args[1])
+			}
+			opts.outputFormat = outputFormat
+			args = args[2:]
+		case redisplayArgColumns:
+			if len(args) < 2 {
+				return nil, fmt.Errorf("'%s' must be followed by a comma separated list of columns", redisplayArgColumns)
+			}
+			// the column list may contain spaces after the commas, so consume the rest of the args
+			// up to the next keyword
+			end := 1
+			for end < len(args) && !strings.EqualFold(args[end], redisplayArgAs) {
+				end++
+			}
+			for _, column := range strings.Split(strings.Join(args[1:end], ","), ",") {
+				if column = strings.TrimSpace(column); column != "" {
+					opts.columns = append(opts.columns, column)
+				}
+			}
+			args = args[end:]
+		default:
+			return nil, fmt.Errorf("unexpected argument '%s'", args[0])
+		}
+	}
+	return opts, nil
+}
+
+// isValidOutputFormat returns whether the format is one of the output formats supported by .output
+func isValidOutputFormat(outputFormat string) bool {
+	for _, arg := range metaQueryDefinitions[constants.CmdOutput].args {
+		if arg.value == outputFormat {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,45 @@
+package metaquery
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRedisplayArgs(t *testing.T) {
+	cases := map[string]*redisplayOptions{
+		`.last`:                               {index: 1},
+		`.last 3`:                             {index: 3},
+		`.last as csv`:                        {index: 1, outputFormat: "csv"},
+		`.last 2 columns name,region`:         {index: 2, columns: []string{"name", "region"}},
+		`.last columns name, region as json`:  {index: 1, outputFormat: "json", columns: []string{"name", "region"}},
+		`.last 2 as line columns name`:        {index: 2, outputFormat: "line", columns: []string{"name"}},
+		`.page AS YAML COLUMNS "a b"`:         {index: 1, outputFormat: "yaml", columns: []string{"a b"}},
+		`.last as md columns name as jsonl;`:  {index: 1, outputFormat: "jsonl", columns: []string{"name"}},
+		`.last 1 columns a as csv columns b`:  {index: 1, outputFormat: "csv", columns: []string{"a", "b"}},
+		`.last 10 as table columns a ,b , c`:  {index: 10, outputFormat: "table", columns: []string{"a", "b", "c"}},
+		`.last 1 columns a,,b`:                {index: 1, columns: []string{"a", "b"}},
+		`.last 1 columns name as csv columns`: nil,
+		`.last 0`:                             nil,
+		`.last as`:                            nil,
+		`.last as parquet`:                    nil,
+		`.last columns`:                       nil,
+		`.last foo`:                           nil,
+	}
+
+	for input, expected := range cases {
+		actual, err := parseRedisplayArgs(getArguments(input))
+		if expected == nil {
+			if err == nil {
+				t.Errorf("%s: expected error, got %v", input, actual)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error %s", input, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%s: %v != %v", input, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/cmdconfig"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// .out [file]
+// write the results of all subsequent queries to the file, or display them again if no file is given
+func setOutputFile(_ context.Context, input *HandlerInput) error {
+	path := outputFileArg(input.Query, constants.CmdOut)
+	if path == "" {
+		cmdconfig.Viper().Set(constants.ConfigKeyOutputFile, "")
+		fmt.Println("Query results will be displayed.")
+		return nil
+	}
+
+	path, err := createOutputFile(path)
+	if err != nil {
+		return err
+	}
+	cmdconfig.Viper().Set(constants.ConfigKeyOutputFile, path)
+	fmt.Printf("Query results will be written to %s. To display results again, run %s\n", path, constants.Bold(constants.CmdOut))
+	return nil
+}
+
+// .once {file}
+// write the result of the next query to the file
+func setOnceOutputFile(_ context.Context, input *HandlerInput) error {
+	path, err := createOutputFile(outputFileArg(input.Query, constants.CmdOnce))
+	if err != nil {
+		return err
+	}
+	cmdconfig.Viper().Set(constants.ConfigKeyOnceOutputFile, path)
+	fmt.Printf("The next query result will be written to %s\n", path)
+	return nil
+}
+
+// outputFileArg returns the file path argument of the metaquery
+// the path is taken from the raw metaquery, as the argument parsing does not preserve whitespace
+func outputFileArg(query, cmd string) string {
+	path := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(query), cmd))
+	path = strings.TrimSpace(strings.TrimSuffix(path, ";"))
+	if len(path) > 1 && (path[0] == '\'' || path[0] == '"') && path[len(path)-1] == path[0] {
+		path = path[1 : len(path)-1]
+	}
+	return path
+}
+
+// createOutputFile creates (or truncates) the output file, returning its absolute path
+// query results are appended to the file as they are executed
+func createOutputFile(path string) (string, error) {
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[2:])
+	}
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to create output file: %s", err.Error())
+	}
+	return path, f.Close()
+}
@@ -0,0 +1,65 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+)
+
+// .set [name [value]]
+// set a query param, or list the query params if no name is given
+func setParam(_ context.Context, input *HandlerInput) error {
+	if input.Params == nil {
+		return fmt.Errorf("query params are not available")
+	}
+	args := input.args()
+	if len(args) == 0 {
+		return listParams(input)
+	}
+
+	// take the value from the raw metaquery, as the argument parsing does not preserve whitespace
+	name := args[0]
+	value := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input.Query), constants.CmdSet))
+	value = strings.TrimSpace(strings.TrimPrefix(value, name))
+	value = strings.TrimSuffix(value, ";")
+	if len(value) > 1 && (value[0] == '\'' || value[0] == '"') && value[len(value)-1] == value[0] {
+		value = value[1 : len(value)-1]
+	}
+	return input.Params.Set(name, value)
+}
+
+// .unset {name}
+// remove a query param
+func unsetParam(_ context.Context, input *HandlerInput) error {
+	if input.Params == nil {
+		return fmt.Errorf("query params are not available")
+	}
+	name := input.args()[0]
+	if !input.Params.Unset(name) {
+		return fmt.Errorf("query param '%s' is not set", name)
+	}
+	return nil
+}
+
+func listParams(input *HandlerInput) error {
+	names := input.Params.Names()
+	if len(names) == 0 {
+		fmt.Printf("No query params set. To set a param, run %s\n", constants.Bold(".set {name} {value}"))
+		return nil
+	}
+
+	rows := make([][]string, len(names))
+	for i, name := range names {
+		value, _ := input.Params.Get(name)
+		rows[i] = []string{name, value}
+	}
+	display.ShowWrappedTable([]string{"name", "value"}, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
+
+	fmt.Printf(`
+To reference a param in a query, use %s
+`, constants.Bold(":{name}"))
+	return nil
+}
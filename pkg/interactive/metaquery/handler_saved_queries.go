@@ -0,0 +1,84 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/workspace"
+)
+
+// .save {name}
+// save the last executed query as a query block in the workspace
+// the sql is saved as entered, i.e. any query param references are saved rather than their values
+func saveQuery(ctx context.Context, input *HandlerInput) error {
+	if input.Workspace == nil {
+		return fmt.Errorf("the workspace is not available")
+	}
+	if input.LastQuery == nil {
+		return fmt.Errorf("no query has been executed - run a query before saving it")
+	}
+	name := strings.TrimPrefix(input.args()[0], "query.")
+	if err := input.Workspace.SaveQuery(ctx, input.Client, name, input.LastQuery.RawSQL); err != nil {
+		return err
+	}
+	if input.OnWorkspaceChanged != nil {
+		input.OnWorkspaceChanged(ctx)
+	}
+
+	fmt.Printf("Saved query %s to %s\n", constants.Bold("query."+name), workspace.SavedQueriesFileName)
+	return nil
+}
+
+// .saved
+// list the queries saved in the workspace
+func listSavedQueries(_ context.Context, input *HandlerInput) error {
+	if input.Workspace == nil {
+		return fmt.Errorf("the workspace is not available")
+	}
+	queries := input.Workspace.SavedQueries()
+	if len(queries) == 0 {
+		fmt.Printf("No saved queries. To save the last query, run %s\n", constants.Bold(".save {name}"))
+		return nil
+	}
+
+	headers := []string{"name", "params", "sql"}
+	rows := make([][]string, len(queries))
+	for i, query := range queries {
+		var params []string
+		for _, param := range query.GetParams() {
+			params = append(params, param.ShortName)
+		}
+		var sql string
+		if query.SQL != nil {
+			sql = strings.TrimSpace(*query.SQL)
+		}
+		rows[i] = []string{query.ShortName, strings.Join(params, ", "), sql}
+	}
+	display.ShowWrappedTable(headers, rows, &display.ShowWrappedTableOptions{AutoMerge: false})
+
+	fmt.Printf(`
+To run a saved query, run %s
+`, constants.Bold(".run {name}"))
+	return nil
+}
+
+// .run {name}[(args)]
+// run a saved (or any named) query
+func runSavedQuery(ctx context.Context, input *HandlerInput) error {
+	if input.Workspace == nil || input.ExecuteQuery == nil {
+		return fmt.Errorf("the workspace is not available")
+	}
+	// take the invocation from the raw metaquery, as the argument parsing does not preserve the args
+	invocation := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input.Query), constants.CmdRun))
+	if !strings.HasPrefix(invocation, "query.") {
+		invocation = "query." + invocation
+	}
+	name := strings.TrimSpace(strings.SplitN(invocation, "(", 2)[0])
+	if _, ok := input.Workspace.GetQueryProvider(name); !ok {
+		return fmt.Errorf("%s not found - run %s to list the saved queries", name, constants.Bold(constants.CmdSaved))
+	}
+	return input.ExecuteQuery(ctx, invocation)
+}
@@ -0,0 +1,42 @@
+package metaquery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/query/querywatch"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// .watch {interval} [query]
+// re-run a query every interval until cancelled - if no query is given, the last query is watched
+func watchQuery(ctx context.Context, input *HandlerInput) error {
+	args := input.args()
+	interval, err := querywatch.ParseInterval(args[0])
+	if err != nil {
+		return err
+	}
+
+	// take the query from the raw metaquery, as the argument parsing does not preserve whitespace
+	query := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(input.Query), constants.CmdWatch))
+	query = strings.TrimSpace(strings.TrimPrefix(query, args[0]))
+
+	var resolvedQuery *modconfig.ResolvedQuery
+	if query == "" {
+		if input.LastQuery == nil {
+			return fmt.Errorf("there is no query to watch - run a query first, or pass a query to %s", constants.CmdWatch)
+		}
+		resolvedQuery = input.LastQuery
+	} else {
+		if input.ResolveQuery == nil {
+			return fmt.Errorf("the workspace is not available")
+		}
+		if resolvedQuery, err = input.ResolveQuery(query); err != nil {
+			return err
+		}
+	}
+
+	return querywatch.Watch(ctx, input.Client, resolvedQuery, interval)
+}
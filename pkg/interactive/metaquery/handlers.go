@@ -84,7 +84,36 @@ func clearScreen(_ context.Context, input *HandlerInput) error {
 }
 
 // .autocomplete
+// turn auto-completion off, or set the completion mode - on, basic or full
 func setAutoComplete(_ context.Context, input *HandlerInput) error {
-	cmdconfig.Viper().Set(constants.ArgAutoComplete, typeHelpers.StringToBool(input.args()[0]))
+	if len(input.args()) == 0 {
+		showAutoCompleteMode()
+		return nil
+	}
+
+	mode := strings.ToLower(input.args()[0])
+	if mode == constants.ArgOff {
+		cmdconfig.Viper().Set(constants.ArgAutoComplete, false)
+		return nil
+	}
+	cmdconfig.Viper().Set(constants.ArgAutoComplete, true)
+	cmdconfig.Viper().Set(constants.ConfigKeyAutoCompleteMode, mode)
 	return nil
 }
+
+func showAutoCompleteMode() {
+	mode := constants.ArgOff
+	if cmdconfig.Viper().GetBool(constants.ArgAutoComplete) {
+		mode = cmdconfig.Viper().GetString(constants.ConfigKeyAutoCompleteMode)
+	}
+	var modes []string
+	for _, arg := range metaQueryDefinitions[constants.CmdAutoComplete].args {
+		modes = append(modes, arg.value)
+	}
+
+	fmt.Printf(`Auto-complete is %s. Available options are: %s`,
+		constants.Bold(mode),
+		constants.Bold(strings.Join(modes, ", ")))
+	// add an empty line here so that the rendering buffer can start from the next line
+	fmt.Println()
+}
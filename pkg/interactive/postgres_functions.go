@@ -0,0 +1,66 @@
+package interactive
+
+import "github.com/c-bata/go-prompt"
+
+// postgresFunctionSuggestions are the commonly used Postgres functions suggested when completing columns
+var postgresFunctionSuggestions = []prompt.Suggest{
+	{Text: "abs", Output: "abs", Description: "Function: abs(number)"},
+	{Text: "age", Output: "age", Description: "Function: age(timestamp, timestamp)"},
+	{Text: "array_agg", Output: "array_agg", Description: "Function: array_agg(expression) - aggregate"},
+	{Text: "array_length", Output: "array_length", Description: "Function: array_length(array, dimension)"},
+	{Text: "array_to_string", Output: "array_to_string", Description: "Function: array_to_string(array, delimiter)"},
+	{Text: "avg", Output: "avg", Description: "Function: avg(expression) - aggregate"},
+	{Text: "bool_and", Output: "bool_and", Description: "Function: bool_and(expression) - aggregate"},
+	{Text: "bool_or", Output: "bool_or", Description: "Function: bool_or(expression) - aggregate"},
+	{Text: "ceil", Output: "ceil", Description: "Function: ceil(number)"},
+	{Text: "coalesce", Output: "coalesce", Description: "Function: coalesce(value, ...)"},
+	{Text: "concat", Output: "concat", Description: "Function: concat(value, ...)"},
+	{Text: "concat_ws", Output: "concat_ws", Description: "Function: concat_ws(separator, value, ...)"},
+	{Text: "count", Output: "count", Description: "Function: count(expression) - aggregate"},
+	{Text: "current_date", Output: "current_date", Description: "Function: current_date"},
+	{Text: "date_part", Output: "date_part", Description: "Function: date_part(field, timestamp)"},
+	{Text: "date_trunc", Output: "date_trunc", Description: "Function: date_trunc(field, timestamp)"},
+	{Text: "extract", Output: "extract", Description: "Function: extract(field from timestamp)"},
+	{Text: "floor", Output: "floor", Description: "Function: floor(number)"},
+	{Text: "format", Output: "format", Description: "Function: format(format, value, ...)"},
+	{Text: "greatest", Output: "greatest", Description: "Function: greatest(value, ...)"},
+	{Text: "jsonb_agg", Output: "jsonb_agg", Description: "Function: jsonb_agg(expression) - aggregate"},
+	{Text: "jsonb_array_elements", Output: "jsonb_array_elements", Description: "Function: jsonb_array_elements(jsonb)"},
+	{Text: "jsonb_array_elements_text", Output: "jsonb_array_elements_text", Description: "Function: jsonb_array_elements_text(jsonb)"},
+	{Text: "jsonb_array_length", Output: "jsonb_array_length", Description: "Function: jsonb_array_length(jsonb)"},
+	{Text: "jsonb_build_array", Output: "jsonb_build_array", Description: "Function: jsonb_build_array(value, ...)"},
+	{Text: "jsonb_build_object", Output: "jsonb_build_object", Description: "Function: jsonb_build_object(key, value, ...)"},
+	{Text: "jsonb_each", Output: "jsonb_each", Description: "Function: jsonb_each(jsonb)"},
+	{Text: "jsonb_each_text", Output: "jsonb_each_text", Description: "Function: jsonb_each_text(jsonb)"},
+	{Text: "jsonb_object_agg", Output: "jsonb_object_agg", Description: "Function: jsonb_object_agg(key, value) - aggregate"},
+	{Text: "jsonb_object_keys", Output: "jsonb_object_keys", Description: "Function: jsonb_object_keys(jsonb)"},
+	{Text: "jsonb_path_query", Output: "jsonb_path_query", Description: "Function: jsonb_path_query(jsonb, path)"},
+	{Text: "jsonb_pretty", Output: "jsonb_pretty", Description: "Function: jsonb_pretty(jsonb)"},
+	{Text: "jsonb_typeof", Output: "jsonb_typeof", Description: "Function: jsonb_typeof(jsonb)"},
+	{Text: "least", Output: "least", Description: "Function: least(value, ...)"},
+	{Text: "left", Output: "left", Description: "Function: left(text, n)"},
+	{Text: "length", Output: "length", Description: "Function: length(text)"},
+	{Text: "lower", Output: "lower", Description: "Function: lower(text)"},
+	{Text: "max", Output: "max", Description: "Function: max(expression) - aggregate"},
+	{Text: "min", Output: "min", Description: "Function: min(expression) - aggregate"},
+	{Text: "now", Output: "now", Description: "Function: now()"},
+	{Text: "nullif", Output: "nullif", Description: "Function: nullif(value1, value2)"},
+	{Text: "position", Output: "position", Description: "Function: position(substring in text)"},
+	{Text: "rank", Output: "rank", Description: "Function: rank() - window"},
+	{Text: "regexp_matches", Output: "regexp_matches", Description: "Function: regexp_matches(text, pattern)"},
+	{Text: "regexp_replace", Output: "regexp_replace", Description: "Function: regexp_replace(text, pattern, replacement)"},
+	{Text: "replace", Output: "replace", Description: "Function: replace(text, from, to)"},
+	{Text: "right", Output: "right", Description: "Function: right(text, n)"},
+	{Text: "round", Output: "round", Description: "Function: round(number, places)"},
+	{Text: "row_number", Output: "row_number", Description: "Function: row_number() - window"},
+	{Text: "split_part", Output: "split_part", Description: "Function: split_part(text, delimiter, n)"},
+	{Text: "string_agg", Output: "string_agg", Description: "Function: string_agg(expression, delimiter) - aggregate"},
+	{Text: "substring", Output: "substring", Description: "Function: substring(text from start for count)"},
+	{Text: "sum", Output: "sum", Description: "Function: sum(expression) - aggregate"},
+	{Text: "to_char", Output: "to_char", Description: "Function: to_char(value, format)"},
+	{Text: "to_jsonb", Output: "to_jsonb", Description: "Function: to_jsonb(value)"},
+	{Text: "to_timestamp", Output: "to_timestamp", Description: "Function: to_timestamp(text, format)"},
+	{Text: "trim", Output: "trim", Description: "Function: trim(text)"},
+	{Text: "unnest", Output: "unnest", Description: "Function: unnest(array)"},
+	{Text: "upper", Output: "upper", Description: "Function: upper(text)"},
+}
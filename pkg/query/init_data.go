@@ -7,11 +7,15 @@ import (
 	"time"
 
 	"github.com/spf13/viper"
+	"github.com/turbot/steampipe-plugin-sdk/v5/sperr"
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/db/db_client"
+	"github.com/turbot/steampipe/pkg/display"
 	"github.com/turbot/steampipe/pkg/error_helpers"
 	"github.com/turbot/steampipe/pkg/export"
 	"github.com/turbot/steampipe/pkg/initialisation"
+	"github.com/turbot/steampipe/pkg/query/queryparams"
+	"github.com/turbot/steampipe/pkg/query/queryscript"
 	"github.com/turbot/steampipe/pkg/statushooks"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/workspace"
@@ -67,7 +71,7 @@ func NewInitData(ctx context.Context, args []string) *InitData {
 }
 
 func queryExporters() []export.Exporter {
-	return []export.Exporter{&export.SnapshotExporter{}}
+	return append([]export.Exporter{&export.SnapshotExporter{}}, display.QueryExporters()...)
 }
 
 func (i *InitData) Cancel() {
@@ -134,6 +138,22 @@ func (i *InitData) init(ctx context.Context, inputVariables *modconfig.ModVariab
 		i.Result.Error = err
 		return
 	}
+	// split any query containing several statements (e.g. a sql file script) into a query per statement
+	resolvedQueries = splitStatements(resolvedQueries)
+	// the results of multiple queries cannot be exported to the same named file
+	if err := validateNamedExport(i.ExportManager, viper.GetStringSlice(constants.ArgExport), resolvedQueries); err != nil {
+		i.Result.Error = err
+		return
+	}
+	// replace any query param references with bind parameters
+	queryParams, err := queryparams.ParseArgs(viper.GetStringSlice(constants.ArgParam))
+	if err != nil {
+		i.Result.Error = err
+		return
+	}
+	for _, resolvedQuery := range resolvedQueries {
+		resolvedQuery.ExecuteSQL, resolvedQuery.Args = queryParams.Substitute(resolvedQuery.ExecuteSQL, resolvedQuery.Args)
+	}
 	// create a cancellable context so that we can cancel the initialisation
 	ctx, cancel := context.WithCancel(ctx)
 	// and store it
@@ -156,3 +176,40 @@ func (i *InitData) init(ctx context.Context, inputVariables *modconfig.ModVariab
 		}),
 	)
 }
+
+// validateNamedExport returns an error if there are several queries to run and an export target is a named file,
+// as the results of each query would overwrite the same file
+// NOTE: this must be called after splitting the queries into statements, as a single script may contain several queries
+func validateNamedExport(exportManager *export.Manager, exports []string, resolvedQueries []*modconfig.ResolvedQuery) error {
+	if len(resolvedQueries) > 1 && exportManager.HasNamedExport(exports) {
+		return sperr.New("named export targets are not supported when running multiple queries - specify the export format instead")
+	}
+	return nil
+}
+
+// splitStatements splits any query which contains several statements (e.g. a script in a sql file) into a query per
+// statement, so that each statement is executed and reported separately
+// queries with args (i.e. parameterised named queries) are not split, as the args apply to the whole query
+func splitStatements(resolvedQueries []*modconfig.ResolvedQuery) []*modconfig.ResolvedQuery {
+	var res []*modconfig.ResolvedQuery
+	for _, resolvedQuery := range resolvedQueries {
+		// empty queries (e.g. empty files) are not resolved
+		if resolvedQuery == nil {
+			continue
+		}
+		statements := queryscript.SplitStatements(resolvedQuery.ExecuteSQL)
+		if len(resolvedQuery.Args) > 0 || len(statements) < 2 {
+			res = append(res, resolvedQuery)
+			continue
+		}
+		for _, statement := range statements {
+			// keep the name of named queries - otherwise the statement is the name
+			name := statement
+			if resolvedQuery.Name != resolvedQuery.ExecuteSQL {
+				name = resolvedQuery.Name
+			}
+			res = append(res, &modconfig.ResolvedQuery{Name: name, ExecuteSQL: statement, RawSQL: statement})
+		}
+	}
+	return res
+}
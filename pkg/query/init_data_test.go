@@ -0,0 +1,66 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/export"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+type namedExportTest struct {
+	queries     []string
+	exports     []string
+	expectError bool
+}
+
+var testCasesNamedExport = map[string]namedExportTest{
+	"single query to named file": {
+		queries: []string{"select 1"},
+		exports: []string{"out.csv"},
+	},
+	"script to named file": {
+		queries:     []string{"select 1; select 2;"},
+		exports:     []string{"out.csv"},
+		expectError: true,
+	},
+	"script to format": {
+		queries: []string{"select 1; select 2;"},
+		exports: []string{"csv"},
+	},
+	"multiple queries to named file": {
+		queries:     []string{"select 1", "select 2"},
+		exports:     []string{"json", "out.csv"},
+		expectError: true,
+	},
+	"multiple queries to format": {
+		queries: []string{"select 1", "select 2"},
+		exports: []string{"csv", "json"},
+	},
+	"no export": {
+		queries: []string{"select 1; select 2;"},
+	},
+}
+
+func TestValidateNamedExport(t *testing.T) {
+	exportManager := export.NewManager()
+	for _, exporter := range queryExporters() {
+		if err := exportManager.Register(exporter); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for name, test := range testCasesNamedExport {
+		var resolvedQueries []*modconfig.ResolvedQuery
+		for _, sql := range test.queries {
+			resolvedQueries = append(resolvedQueries, &modconfig.ResolvedQuery{Name: sql, ExecuteSQL: sql, RawSQL: sql})
+		}
+		// validate the queries as they are executed, i.e. with scripts split into statements
+		err := validateNamedExport(exportManager, test.exports, splitStatements(resolvedQueries))
+		if test.expectError && err == nil {
+			t.Errorf("Test: '%s'' FAILED : expected an error", name)
+		}
+		if !test.expectError && err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+		}
+	}
+}
@@ -3,6 +3,8 @@ package queryexecute
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/viper"
@@ -13,8 +15,12 @@ import (
 	"github.com/turbot/steampipe/pkg/db/db_common"
 	"github.com/turbot/steampipe/pkg/display"
 	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/export"
 	"github.com/turbot/steampipe/pkg/interactive"
 	"github.com/turbot/steampipe/pkg/query"
+	"github.com/turbot/steampipe/pkg/query/queryexplain"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/query/querywatch"
 	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
 	"github.com/turbot/steampipe/pkg/utils"
 )
@@ -57,6 +63,10 @@ func RunBatchSession(ctx context.Context, initData *query.InitData) (int, error)
 		}
 	}
 
+	if viper.IsSet(constants.ArgWatchInterval) {
+		return 0, watchQuery(ctx, initData)
+	}
+
 	failures := 0
 	if len(initData.Queries) > 0 {
 		// if we have resolved any queries, run them
@@ -77,9 +87,49 @@ func executeQueries(ctx context.Context, initData *query.InitData) int {
 
 	var err error
 
+	explainMode := viper.GetString(constants.ArgExplain)
+
+	// unless explaining (which uses sessions of its own), run all the queries in a single session,
+	// so statements of a script can use the temporary tables, settings or transaction created by earlier statements
+	var session *db_common.DatabaseSession
+	if explainMode == constants.ArgOff {
+		sessionResult := initData.Client.AcquireSession(ctx)
+		if sessionResult.Error != nil {
+			error_helpers.ShowWarning(fmt.Sprintf("executeQueries: failed to acquire a database session: %v", sessionResult.Error))
+			return len(initData.Queries)
+		}
+		session = sessionResult.Session
+		defer func() {
+			// we need to do this in a closure, otherwise the ctx will be evaluated immediately
+			// and not in call-time
+			session.Close(error_helpers.IsContextCanceled(ctx))
+		}()
+	}
+
+	singleTransaction := viper.GetBool(constants.ArgSingleTransaction)
+	if singleTransaction {
+		if _, err := session.Connection.Exec(ctx, "begin"); err != nil {
+			error_helpers.ShowWarning(fmt.Sprintf("executeQueries: failed to start transaction: %v", error_helpers.DecodePgError(err)))
+			return len(initData.Queries)
+		}
+	}
+	// there is no point continuing after a failure in a transaction, as postgres will reject the remaining statements
+	stopOnError := singleTransaction || viper.GetString(constants.ArgOnError) == constants.ArgStop
+
 	for i, q := range initData.Queries {
-		// if executeQuery fails it returns err, else it returns the number of rows that returned errors while execution
-		if err, failures = executeQuery(ctx, initData.Client, q); err != nil {
+		if len(initData.Queries) > 1 && showStatementHeaders() {
+			display.ShowStatementHeader(i, len(initData.Queries), q.ExecuteSQL)
+		}
+		rowErrors := 0
+		if explainMode != constants.ArgOff {
+			// show the query plan rather than the query results
+			err = explainQuery(ctx, initData, q, explainMode == constants.ArgAnalyze)
+		} else {
+			// if executeQuery fails it returns err, else it returns the number of rows that returned errors while execution
+			err, rowErrors = executeQuery(ctx, initData, session, q, exportExecutionName(initData.Queries, i))
+		}
+		failures += rowErrors
+		if err != nil {
 			failures++
 			error_helpers.ShowWarning(fmt.Sprintf("executeQueries: query %d of %d failed: %v", i+1, len(initData.Queries), error_helpers.DecodePgError(err)))
 			// if timing flag is enabled, show the time taken for the query to fail
@@ -87,6 +137,12 @@ func executeQueries(ctx context.Context, initData *query.InitData) int {
 				display.DisplayErrorTiming(t)
 			}
 		}
+		if (err != nil || rowErrors > 0) && stopOnError {
+			if remaining := len(initData.Queries) - i - 1; remaining > 0 {
+				error_helpers.ShowWarning(fmt.Sprintf("executeQueries: not running the remaining %d %s", remaining, utils.Pluralize("query", remaining)))
+			}
+			break
+		}
 		// TODO move into display layer
 		// Only show the blank line between queries, not after the last one
 		if (i < len(initData.Queries)-1) && showBlankLineBetweenResults() {
@@ -94,30 +150,134 @@ func executeQueries(ctx context.Context, initData *query.InitData) int {
 		}
 	}
 
+	if singleTransaction {
+		if err := endTransaction(ctx, session, failures == 0); err != nil {
+			failures++
+			error_helpers.ShowWarning(fmt.Sprintf("executeQueries: %v", error_helpers.DecodePgError(err)))
+		}
+	}
+
 	return failures
 }
 
-func executeQuery(ctx context.Context, client db_common.Client, resolvedQuery *modconfig.ResolvedQuery) (error, int) {
+// endTransaction commits the transaction started for --single-transaction, or rolls it back if any query failed
+func endTransaction(ctx context.Context, session *db_common.DatabaseSession, commit bool) error {
+	// if the execution was cancelled, the context can no longer be used
+	if error_helpers.IsContextCanceled(ctx) {
+		ctx = context.Background()
+		commit = false
+	}
+	if !commit {
+		error_helpers.ShowWarning("rolling back the transaction, as not all queries succeeded")
+		if _, err := session.Connection.Exec(ctx, "rollback"); err != nil {
+			return fmt.Errorf("failed to roll back transaction: %w", err)
+		}
+		return nil
+	}
+	if _, err := session.Connection.Exec(ctx, "commit"); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func executeQuery(ctx context.Context, initData *query.InitData, session *db_common.DatabaseSession, resolvedQuery *modconfig.ResolvedQuery, exportName string) (error, int) {
 	utils.LogTime("query.execute.executeQuery start")
 	defer utils.LogTime("query.execute.executeQuery end")
 
 	// the db executor sends result data over resultsStreamer
-	resultsStreamer, err := db_common.ExecuteQuery(ctx, client, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
+	resultsStreamer, err := db_common.ExecuteQueryInSession(ctx, initData.Client, session, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
 	if err != nil {
 		return err, 0
 	}
 
+	exportArgs := viper.GetStringSlice(constants.ArgExport)
 	rowErrors := 0 // get the number of rows that returned an error
 	// print the data as it comes
 	for r := range resultsStreamer.Results {
-		rowErrors = display.ShowOutput(ctx, r)
+		if len(exportArgs) > 0 {
+			rowErrors, err = displayAndExportResult(ctx, initData.ExportManager, r, exportName, exportArgs)
+		} else {
+			rowErrors = display.ShowOutput(ctx, r)
+		}
 		// signal to the resultStreamer that we are done with this result
 		resultsStreamer.AllResultsRead()
 	}
-	return nil, rowErrors
+	return err, rowErrors
+}
+
+// watchQuery re-runs the query every interval until cancelled, redrawing the result in place
+func watchQuery(ctx context.Context, initData *query.InitData) error {
+	interval, err := querywatch.ParseInterval(viper.GetString(constants.ArgWatchInterval))
+	if err != nil {
+		return err
+	}
+	if len(initData.Queries) != 1 {
+		return fmt.Errorf("--%s requires a single query - got %d", constants.ArgWatchInterval, len(initData.Queries))
+	}
+	return querywatch.Watch(ctx, initData.Client, initData.Queries[0], interval)
+}
+
+// explainQuery displays the query plan for the query - if analyze is set the query is executed
+// and the plugin scans are included in the plan
+func explainQuery(ctx context.Context, initData *query.InitData, resolvedQuery *modconfig.ResolvedQuery, analyze bool) error {
+	explanation, err := queryexplain.Explain(ctx, initData.Client, analyze, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
+	if err != nil {
+		return err
+	}
+	display.ShowExplanation(explanation)
+	return nil
+}
+
+// displayAndExportResult displays the result and exports it to each of the export targets
+// the result is buffered so that it can be read for display and for each export
+func displayAndExportResult(ctx context.Context, exportManager *export.Manager, result *queryresult.Result, exportName string, exportArgs []string) (int, error) {
+	bufferedResult := result.Buffer()
+	rowErrors := display.ShowOutput(ctx, bufferedResult.NewResult())
+
+	var exportMsg []string
+	var errors []error
+	for _, exportArg := range exportArgs {
+		msg, err := exportManager.DoExport(ctx, exportName, bufferedResult.NewResult(), []string{exportArg})
+		if err != nil {
+			errors = append(errors, err)
+			continue
+		}
+		exportMsg = append(exportMsg, msg...)
+	}
+
+	// print the location where the files were exported
+	// (write to stderr, so the location is not mixed up with the query output)
+	if len(exportMsg) > 0 && viper.GetBool(constants.ArgProgress) {
+		fmt.Fprintln(os.Stderr, strings.Join(exportMsg, "\n"))
+	}
+	if err := error_helpers.CombineErrors(errors...); err != nil {
+		return rowErrors, fmt.Errorf("failed to export query result: %s", err.Error())
+	}
+	return rowErrors, nil
+}
+
+// exportExecutionName returns the name used to generate the export file names for the query at the given index
+// - named queries use the query name, other queries use 'query'
+// - if there are multiple queries, the query index is appended
+func exportExecutionName(queries []*modconfig.ResolvedQuery, idx int) string {
+	resolvedQuery := queries[idx]
+	name := "query"
+	if resolvedQuery.Name != resolvedQuery.ExecuteSQL {
+		name = resolvedQuery.Name
+	}
+	if len(queries) > 1 {
+		name = fmt.Sprintf("%s_%d", name, idx+1)
+	}
+	return name
 }
 
 // if we are displaying csv with no header, do not include lines between the query results
 func showBlankLineBetweenResults() bool {
 	return !(viper.GetString(constants.ArgOutput) == "csv" && !viper.GetBool(constants.ArgHeader))
 }
+
+// only show a header for each query when displaying as a table or lines, so the other formats can still be parsed
+func showStatementHeaders() bool {
+	output := viper.GetString(constants.ArgOutput)
+	return output == constants.OutputFormatTable || output == constants.OutputFormatLine
+}
@@ -0,0 +1,188 @@
+package queryexplain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+const nodeTypeForeignScan = "Foreign Scan"
+
+// PlanNode is a node of a Postgres query plan, as returned by EXPLAIN (FORMAT JSON)
+type PlanNode struct {
+	NodeType     string   `json:"Node Type"`
+	RelationName string   `json:"Relation Name"`
+	Schema       string   `json:"Schema"`
+	Alias        string   `json:"Alias"`
+	JoinType     string   `json:"Join Type"`
+	Filter       string   `json:"Filter"`
+	JoinFilter   string   `json:"Join Filter"`
+	HashCond     string   `json:"Hash Cond"`
+	IndexCond    string   `json:"Index Cond"`
+	SortKey      []string `json:"Sort Key"`
+	StartupCost  float64  `json:"Startup Cost"`
+	TotalCost    float64  `json:"Total Cost"`
+	PlanRows     float64  `json:"Plan Rows"`
+	// actual values are only populated if the query was analyzed
+	ActualTotalTime *float64    `json:"Actual Total Time"`
+	ActualRows      *float64    `json:"Actual Rows"`
+	ActualLoops     *float64    `json:"Actual Loops"`
+	Plans           []*PlanNode `json:"Plans"`
+
+	// the plugin scans made for this node - only populated for foreign scans if the query was analyzed
+	Scans []*queryresult.ScanMetadataRow `json:"-"`
+}
+
+// IsForeignScan returns whether this node is a scan of a plugin table
+func (n *PlanNode) IsForeignScan() bool {
+	return n.NodeType == nodeTypeForeignScan
+}
+
+// IsFullScan returns whether this is a foreign scan for which no quals were pushed down to the plugin
+// NOTE: this can only be determined if the query was analyzed
+func (n *PlanNode) IsFullScan() bool {
+	if !n.IsForeignScan() || len(n.Scans) == 0 {
+		return false
+	}
+	for _, scan := range n.Scans {
+		if len(scan.Quals) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Explanation is the Postgres plan for a query, combined with the plugin scans made when executing it
+type Explanation struct {
+	Plan          *PlanNode `json:"Plan"`
+	PlanningTime  *float64  `json:"Planning Time"`
+	ExecutionTime *float64  `json:"Execution Time"`
+	// was the query executed to build this explanation
+	Analyzed bool `json:"-"`
+	// plugin scans which could not be associated with a plan node
+	UnmatchedScans []*queryresult.ScanMetadataRow `json:"-"`
+}
+
+// Explain returns the query plan for the query
+// if analyze is set the query is executed, and the plugin scans made are added to the foreign scan nodes of the plan
+func Explain(ctx context.Context, client db_common.Client, analyze bool, query string, args ...any) (*Explanation, error) {
+	query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+	if query == "" {
+		return nil, fmt.Errorf("no query to explain")
+	}
+
+	// acquire a session - the scan metadata must be read from the session which executed the query
+	sessionResult := client.AcquireSession(ctx)
+	if sessionResult.Error != nil {
+		return nil, sessionResult.Error
+	}
+	defer func() {
+		sessionResult.Session.Close(error_helpers.IsContextCanceled(ctx))
+	}()
+
+	options := "verbose, format json"
+	if analyze {
+		options = "analyze, " + options
+	}
+	res, err := client.ExecuteSyncInSession(ctx, sessionResult.Session, fmt.Sprintf("explain (%s) %s", options, query), args...)
+	if err != nil {
+		return nil, err
+	}
+	explanation, err := parseExplainResult(res)
+	if err != nil {
+		return nil, err
+	}
+
+	if analyze {
+		explanation.Analyzed = true
+		scans, err := db_common.LoadScanMetadata(ctx, sessionResult.Session)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load scan metadata: %s", err.Error())
+		}
+		explanation.UnmatchedScans = assignScans(explanation.Plan, scans)
+	}
+	return explanation, nil
+}
+
+// parseExplainResult parses the single JSON value returned by EXPLAIN (FORMAT JSON)
+func parseExplainResult(res *queryresult.SyncQueryResult) (*Explanation, error) {
+	if len(res.Rows) == 0 {
+		return nil, fmt.Errorf("explain returned no rows")
+	}
+	row, ok := res.Rows[0].(*queryresult.RowResult)
+	if !ok || len(row.Data) == 0 {
+		return nil, fmt.Errorf("unexpected explain result")
+	}
+
+	var planJSON []byte
+	switch v := row.Data[0].(type) {
+	case string:
+		planJSON = []byte(v)
+	case []byte:
+		planJSON = v
+	default:
+		// the json has already been decoded - re-encode it so we can decode into our structs
+		var err error
+		if planJSON, err = json.Marshal(v); err != nil {
+			return nil, err
+		}
+	}
+
+	var explanations []*Explanation
+	if err := json.Unmarshal(planJSON, &explanations); err != nil {
+		return nil, fmt.Errorf("failed to parse query plan: %s", err.Error())
+	}
+	if len(explanations) == 0 || explanations[0].Plan == nil {
+		return nil, fmt.Errorf("explain returned no plan")
+	}
+	return explanations[0], nil
+}
+
+// assignScans adds each scan to the foreign scan nodes of the plan for the same table and connection
+// (or the same table, for aggregator connections) and returns any scans which could not be assigned
+func assignScans(plan *PlanNode, scans []*queryresult.ScanMetadataRow) []*queryresult.ScanMetadataRow {
+	assigned := make(map[*queryresult.ScanMetadataRow]bool)
+	walkPlan(plan, func(node *PlanNode) {
+		if !node.IsForeignScan() {
+			return
+		}
+		var tableScans []*queryresult.ScanMetadataRow
+		for _, scan := range scans {
+			if scan.Table != node.RelationName {
+				continue
+			}
+			if scan.Connection == node.Schema {
+				node.Scans = append(node.Scans, scan)
+				assigned[scan] = true
+			}
+			tableScans = append(tableScans, scan)
+		}
+		// if there are no scans for the schema, this may be an aggregator - use all scans for the table
+		if len(node.Scans) == 0 {
+			node.Scans = tableScans
+			for _, scan := range tableScans {
+				assigned[scan] = true
+			}
+		}
+	})
+
+	var unmatched []*queryresult.ScanMetadataRow
+	for _, scan := range scans {
+		if !assigned[scan] {
+			unmatched = append(unmatched, scan)
+		}
+	}
+	return unmatched
+}
+
+func walkPlan(node *PlanNode, f func(*PlanNode)) {
+	f(node)
+	for _, child := range node.Plans {
+		walkPlan(child, f)
+	}
+}
@@ -0,0 +1,52 @@
+package queryexplain
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+const testPlan = `[{"Plan": {"Node Type": "Hash Join", "Join Type": "Inner", "Startup Cost": 1.5, "Total Cost": 10.25, "Plan Rows": 4,
+  "Hash Cond": "(b.region = r.name)",
+  "Plans": [
+    {"Node Type": "Foreign Scan", "Relation Name": "aws_s3_bucket", "Schema": "aws", "Alias": "b", "Startup Cost": 0, "Total Cost": 4, "Plan Rows": 100},
+    {"Node Type": "Hash", "Startup Cost": 0, "Total Cost": 2, "Plan Rows": 10, "Plans": [
+      {"Node Type": "Foreign Scan", "Relation Name": "aws_region", "Schema": "aws_all", "Alias": "r", "Filter": "(r.name = 'us-east-1'::text)", "Startup Cost": 0, "Total Cost": 2, "Plan Rows": 10}
+    ]}
+  ]},
+  "Planning Time": 0.5, "Execution Time": 12.25}]`
+
+func TestParseAndAssignScans(t *testing.T) {
+	res := &queryresult.SyncQueryResult{Rows: []interface{}{&queryresult.RowResult{Data: []interface{}{testPlan}}}}
+	explanation, err := parseExplainResult(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if explanation.Plan.NodeType != "Hash Join" || len(explanation.Plan.Plans) != 2 {
+		t.Fatalf("unexpected plan %+v", explanation.Plan)
+	}
+	if explanation.ExecutionTime == nil || *explanation.ExecutionTime != 12.25 {
+		t.Fatalf("unexpected execution time %v", explanation.ExecutionTime)
+	}
+
+	bucketScan := &queryresult.ScanMetadataRow{Connection: "aws", Table: "aws_s3_bucket"}
+	// aws_all is an aggregator, so the region scans are for the child connections
+	regionScan1 := &queryresult.ScanMetadataRow{Connection: "aws_1", Table: "aws_region", Quals: []grpc.SerializableQual{{Column: "name", Operator: "=", Value: "us-east-1"}}}
+	regionScan2 := &queryresult.ScanMetadataRow{Connection: "aws_2", Table: "aws_region", Quals: []grpc.SerializableQual{{Column: "name", Operator: "=", Value: "us-east-1"}}}
+	otherScan := &queryresult.ScanMetadataRow{Connection: "aws", Table: "aws_account"}
+
+	unmatched := assignScans(explanation.Plan, []*queryresult.ScanMetadataRow{bucketScan, regionScan1, regionScan2, otherScan})
+
+	bucketNode := explanation.Plan.Plans[0]
+	regionNode := explanation.Plan.Plans[1].Plans[0]
+	if len(bucketNode.Scans) != 1 || !bucketNode.IsFullScan() {
+		t.Errorf("expected bucket node to have 1 full scan, got %d scans", len(bucketNode.Scans))
+	}
+	if len(regionNode.Scans) != 2 || regionNode.IsFullScan() {
+		t.Errorf("expected region node to have 2 scans with quals, got %d scans", len(regionNode.Scans))
+	}
+	if len(unmatched) != 1 || unmatched[0] != otherScan {
+		t.Errorf("expected 1 unmatched scan, got %d", len(unmatched))
+	}
+}
@@ -2,23 +2,54 @@ package queryhistory
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"time"
 
+	"github.com/turbot/go-kit/helpers"
 	"github.com/turbot/steampipe/pkg/constants"
 	"github.com/turbot/steampipe/pkg/filepaths"
 )
 
+// HistoryEntry is a single query history item, along with the metadata of its execution
+type HistoryEntry struct {
+	Id            int       `json:"id"`
+	Query         string    `json:"query"`
+	Timestamp     time.Time `json:"timestamp"`
+	DurationMs    int64     `json:"duration_ms"`
+	RowCount      int       `json:"row_count"`
+	Error         string    `json:"error,omitempty"`
+	WorkspacePath string    `json:"workspace_path,omitempty"`
+	SearchPath    []string  `json:"search_path,omitempty"`
+}
+
+// SetResult sets the execution metadata of the entry
+func (e *HistoryEntry) SetResult(duration time.Duration, rowCount int, err error) {
+	e.DurationMs = duration.Milliseconds()
+	e.RowCount = rowCount
+	e.Error = ""
+	if err != nil {
+		e.Error = err.Error()
+	}
+}
+
 // QueryHistory :: struct for working with history in the interactive mode
+// history is stored separately for each workspace
 type QueryHistory struct {
-	history []string
+	history       []*HistoryEntry
+	workspacePath string
 }
 
-// New creates a new QueryHistory object
-func New() (*QueryHistory, error) {
-	history := &QueryHistory{history: []string{}}
+// New creates a new QueryHistory object, loading the history for the given workspace
+func New(workspacePath string) (*QueryHistory, error) {
+	history := &QueryHistory{
+		history:       []*HistoryEntry{},
+		workspacePath: workspacePath,
+	}
 	err := history.load()
 	if err != nil {
 		return nil, err
@@ -26,16 +57,19 @@ func New() (*QueryHistory, error) {
 	return history, nil
 }
 
-// Push adds a string to the history queue trimming to maxHistorySize if necessary
-func (q *QueryHistory) Push(query string) {
+// Push adds a query to the history queue trimming to maxHistorySize if necessary
+// and returns the history entry (or nil if the query was not stored)
+func (q *QueryHistory) Push(query string) *HistoryEntry {
 	if len(strings.TrimSpace(query)) == 0 {
 		// do not store a blank query
-		return
+		return nil
 	}
 
 	// do a strict compare to see if we have this same exact query as the most recent history item
-	if lastElement := q.Peek(); lastElement != nil && (*lastElement) == query {
-		return
+	// if so, update the timestamp of that item rather than adding a new one
+	if lastElement := q.Peek(); lastElement != nil && lastElement.Query == query {
+		lastElement.Timestamp = time.Now()
+		return lastElement
 	}
 
 	// limit the history length to HistorySize
@@ -45,16 +79,23 @@ func (q *QueryHistory) Push(query string) {
 	}
 
 	// append the new entry
-	q.history = append(q.history, query)
+	entry := &HistoryEntry{
+		Id:            q.nextId(),
+		Query:         query,
+		Timestamp:     time.Now(),
+		WorkspacePath: q.workspacePath,
+	}
+	q.history = append(q.history, entry)
+	return entry
 }
 
 // Peek returns the last element of the history stack.
 // returns nil if there is no history
-func (q *QueryHistory) Peek() *string {
+func (q *QueryHistory) Peek() *HistoryEntry {
 	if len(q.history) == 0 {
 		return nil
 	}
-	return &q.history[len(q.history)-1]
+	return q.history[len(q.history)-1]
 }
 
 // Persist writes the history to the filesystem
@@ -64,8 +105,7 @@ func (q *QueryHistory) Persist() error {
 	defer func() {
 		file.Close()
 	}()
-	path := filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
-	file, err = os.Create(path)
+	file, err = os.Create(q.path())
 	if err != nil {
 		return err
 	}
@@ -78,13 +118,92 @@ func (q *QueryHistory) Persist() error {
 	return jsonEncoder.Encode(q.history)
 }
 
-// Get returns the full history
+// Get returns the queries of the full history
 func (q *QueryHistory) Get() []string {
+	queries := make([]string, len(q.history))
+	for i, entry := range q.history {
+		queries[i] = entry.Query
+	}
+	return queries
+}
+
+// Entries returns the full history
+func (q *QueryHistory) Entries() []*HistoryEntry {
 	return q.history
 }
 
+// Search returns the history entries whose query contains the search string (case insensitive)
+// if the search string is empty, all entries are returned
+func (q *QueryHistory) Search(search string) []*HistoryEntry {
+	search = strings.ToLower(strings.TrimSpace(search))
+	if search == "" {
+		return q.history
+	}
+	var res []*HistoryEntry
+	for _, entry := range q.history {
+		if strings.Contains(strings.ToLower(entry.Query), search) {
+			res = append(res, entry)
+		}
+	}
+	return res
+}
+
+// GetEntry returns the history entry with the given id, or an error if there is no such entry
+func (q *QueryHistory) GetEntry(id int) (*HistoryEntry, error) {
+	for _, entry := range q.history {
+		if entry.Id == id {
+			return entry, nil
+		}
+	}
+	return nil, fmt.Errorf("history entry %d not found", id)
+}
+
+func (q *QueryHistory) nextId() int {
+	if lastElement := q.Peek(); lastElement != nil {
+		return lastElement.Id + 1
+	}
+	return 1
+}
+
+// path returns the path of the history file for the workspace
+func (q *QueryHistory) path() string {
+	return filepath.Join(filepaths.EnsureQueryHistoryDir(), historyFileName(q.workspacePath))
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// historyFileName returns the history file name for the workspace
+// this is the workspace folder name, with a hash of the full path to ensure uniqueness
+func historyFileName(workspacePath string) string {
+	name := unsafeFileNameChars.ReplaceAllString(filepath.Base(workspacePath), "_")
+	return fmt.Sprintf("%s_%s.json", name, helpers.GetMD5Hash(workspacePath)[:8])
+}
+
 // loads up the history from the file where it is persisted
 func (q *QueryHistory) load() error {
+	file, err := os.Open(q.path())
+	if err != nil {
+		// if there is no history for this workspace, load the legacy history file
+		if os.IsNotExist(err) {
+			return q.loadLegacy()
+		}
+		return err
+
+	}
+	defer file.Close()
+
+	decoder := json.NewDecoder(file)
+	err = decoder.Decode(&q.history)
+	// ignore EOF (caused by empty file)
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// loadLegacy loads the history from the legacy history file, which is shared by all workspaces
+// and contains a JSON array of query strings
+func (q *QueryHistory) loadLegacy() error {
 	path := filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
 	file, err := os.Open(path)
 	if err != nil {
@@ -97,11 +216,35 @@ func (q *QueryHistory) load() error {
 	}
 	defer file.Close()
 
+	var queries []string
 	decoder := json.NewDecoder(file)
-	err = decoder.Decode(&q.history)
+	err = decoder.Decode(&queries)
 	// ignore EOF (caused by empty file)
 	if err == io.EOF {
 		return nil
 	}
-	return err
+	if err != nil {
+		return err
+	}
+	for i, query := range queries {
+		q.history = append(q.history, &HistoryEntry{Id: i + 1, Query: query})
+	}
+	return nil
+}
+
+// TableRows returns the headers and rows used to display the given history entries
+func TableRows(entries []*HistoryEntry) ([]string, [][]string) {
+	headers := []string{"id", "time", "duration", "rows", "error", "query"}
+	rows := make([][]string, len(entries))
+	for i, entry := range entries {
+		var timestamp, duration, rowCount string
+		// legacy entries have no execution metadata
+		if !entry.Timestamp.IsZero() {
+			timestamp = entry.Timestamp.Local().Format(time.DateTime)
+			duration = (time.Duration(entry.DurationMs) * time.Millisecond).String()
+			rowCount = fmt.Sprintf("%d", entry.RowCount)
+		}
+		rows[i] = []string{fmt.Sprintf("%d", entry.Id), timestamp, duration, rowCount, entry.Error, entry.Query}
+	}
+	return headers, rows
 }
@@ -0,0 +1,62 @@
+package queryhistory
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/filepaths"
+)
+
+func TestQueryHistory(t *testing.T) {
+	filepaths.SteampipeDir = t.TempDir()
+
+	// write a legacy history file
+	legacyPath := filepath.Join(filepaths.EnsureInternalDir(), constants.HistoryFile)
+	if err := os.WriteFile(legacyPath, []byte(`["select 1", "select * from aws_s3_bucket"]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	history, err := New("/work/space_a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(history.Entries()); got != 2 {
+		t.Fatalf("expected 2 legacy entries, got %d", got)
+	}
+
+	// pushing the same query as the last entry should not add an entry
+	if entry := history.Push("select * from aws_s3_bucket"); entry == nil || entry.Id != 2 {
+		t.Fatalf("expected existing entry 2 to be returned, got %v", entry)
+	}
+	entry := history.Push("select name from aws_s3_bucket")
+	if entry == nil || entry.Id != 3 || entry.WorkspacePath != "/work/space_a" {
+		t.Fatalf("unexpected entry %v", entry)
+	}
+	if history.Push("  ") != nil {
+		t.Fatal("expected blank query not to be stored")
+	}
+
+	if got := len(history.Search("AWS_S3")); got != 2 {
+		t.Fatalf("expected 2 search results, got %d", got)
+	}
+	if _, err := history.GetEntry(4); err == nil {
+		t.Fatal("expected error getting missing entry")
+	}
+
+	// history is persisted per workspace
+	if err := history.Persist(); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := New("/work/space_a")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(reloaded.Entries()); got != 3 {
+		t.Fatalf("expected 3 persisted entries, got %d", got)
+	}
+	if historyFileName("/work/space_a") == historyFileName("/other/space_a") {
+		t.Fatal("expected workspaces with the same folder name to have different history files")
+	}
+}
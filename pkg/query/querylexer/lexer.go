@@ -0,0 +1,155 @@
+package querylexer
+
+import (
+	"regexp"
+	"strings"
+)
+
+type TokenType int
+
+const (
+	// TokenOther is any other single character, or a '::' type cast
+	TokenOther TokenType = iota
+	TokenWhitespace
+	// TokenComment is a line (--) or block (/* */) comment - block comments may be nested
+	TokenComment
+	// TokenString is a string literal, an escape string literal (E'...') or a dollar quoted string
+	TokenString
+	// TokenQuotedIdentifier is a double quoted identifier
+	TokenQuotedIdentifier
+	// TokenIdentifier is an unquoted identifier or keyword
+	TokenIdentifier
+)
+
+// Token is a lexical token of a SQL string
+type Token struct {
+	Type  TokenType
+	Value string
+}
+
+// IsSpaceOrComment returns whether the token is whitespace or a comment
+func (t Token) IsSpaceOrComment() bool {
+	return t.Type == TokenWhitespace || t.Type == TokenComment
+}
+
+// dollarQuoteRegex matches the opening tag of a dollar quoted string, e.g. $$ or $body$
+var dollarQuoteRegex = regexp.MustCompile(`^\$([a-zA-Z_][a-zA-Z0-9_]*)?\$`)
+
+// Lex splits the sql into tokens - concatenating the token values gives the original sql
+//
+// the lexer only distinguishes the tokens required to find the parts of the sql which are quoted
+// (string literals, quoted identifiers, dollar quoted strings and comments) - unterminated tokens run
+// to the end of the sql
+func Lex(sql string) []Token {
+	var res []Token
+	for i := 0; i < len(sql); {
+		rest := sql[i:]
+		var token Token
+		switch {
+		case isSpace(rest[0]):
+			token = Token{TokenWhitespace, rest[:spaceLength(rest)]}
+		case strings.HasPrefix(rest, "--"):
+			token = Token{TokenComment, rest[:tokenLength(rest, strings.IndexByte(rest, '\n'), 0)]}
+		case strings.HasPrefix(rest, "/*"):
+			token = Token{TokenComment, rest[:blockCommentLength(rest)]}
+		case (rest[0] == 'e' || rest[0] == 'E') && len(rest) > 1 && rest[1] == '\'':
+			// escape string, e.g. E'it\'s' - a backslash escapes the following character
+			token = Token{TokenString, rest[:1+quotedLength(rest[1:], true)]}
+		case rest[0] == '\'':
+			token = Token{TokenString, rest[:quotedLength(rest, false)]}
+		case rest[0] == '"':
+			token = Token{TokenQuotedIdentifier, rest[:quotedLength(rest, false)]}
+		case rest[0] == '$' && dollarQuoteRegex.MatchString(rest):
+			tag := dollarQuoteRegex.FindString(rest)
+			token = Token{TokenString, rest[:tokenLength(rest, strings.Index(rest[len(tag):], tag), 2*len(tag))]}
+		case isIdentifierStart(rest[0]):
+			token = Token{TokenIdentifier, rest[:identifierLength(rest)]}
+		case strings.HasPrefix(rest, "::"):
+			token = Token{TokenOther, "::"}
+		default:
+			token = Token{TokenOther, rest[:1]}
+		}
+		res = append(res, token)
+		i += len(token.Value)
+	}
+	return res
+}
+
+// tokenLength returns the length of the token at the start of s
+// idx is the index of the token terminator (relative to the start of the search) and extra the number
+// of bytes to add to this to reach the end of the token - if idx is -1 the token runs to the end of s
+func tokenLength(s string, idx, extra int) int {
+	if idx == -1 || idx+extra > len(s) {
+		return len(s)
+	}
+	return idx + extra
+}
+
+// quotedLength returns the length of the quoted token at the start of s - a doubled quote is an escaped quote,
+// and if backslashEscapes is set, a backslash escapes the following character
+func quotedLength(s string, backslashEscapes bool) int {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if backslashEscapes {
+				i++
+			}
+		case quote:
+			if i+1 < len(s) && s[i+1] == quote {
+				i++
+				continue
+			}
+			return i + 1
+		}
+	}
+	return len(s)
+}
+
+// blockCommentLength returns the length of the block comment at the start of s - block comments may be nested
+func blockCommentLength(s string) int {
+	depth := 0
+	for i := 0; i < len(s)-1; i++ {
+		switch s[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+func identifierLength(s string) int {
+	i := 1
+	for i < len(s) && isIdentifierChar(s[i]) {
+		i++
+	}
+	return i
+}
+
+func spaceLength(s string) int {
+	i := 1
+	for i < len(s) && isSpace(s[i]) {
+		i++
+	}
+	return i
+}
+
+// isIdentifierStart returns whether an unquoted identifier may start with c (any non-ascii byte is allowed)
+func isIdentifierStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isIdentifierChar(c byte) bool {
+	return isIdentifierStart(c) || c == '$' || (c >= '0' && c <= '9')
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
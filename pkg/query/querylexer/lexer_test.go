@@ -0,0 +1,70 @@
+package querylexer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type lexTest struct {
+	sql      string
+	expected []Token
+}
+
+var testCasesLex = map[string]lexTest{
+	"identifiers and punctuation": {
+		sql: "select a1$, :b::int",
+		expected: []Token{
+			{TokenIdentifier, "select"}, {TokenWhitespace, " "}, {TokenIdentifier, "a1$"}, {TokenOther, ","},
+			{TokenWhitespace, " "}, {TokenOther, ":"}, {TokenIdentifier, "b"}, {TokenOther, "::"}, {TokenIdentifier, "int"},
+		},
+	},
+	"string literal": {
+		sql:      `'it''s;'x`,
+		expected: []Token{{TokenString, `'it''s;'`}, {TokenIdentifier, "x"}},
+	},
+	"escape string": {
+		sql:      `E'it\'s'e'\\'`,
+		expected: []Token{{TokenString, `E'it\'s'`}, {TokenString, `e'\\'`}},
+	},
+	"identifier ending in e": {
+		sql:      `name'x'`,
+		expected: []Token{{TokenIdentifier, "name"}, {TokenString, "'x'"}},
+	},
+	"quoted identifier": {
+		sql:      `"a""b"`,
+		expected: []Token{{TokenQuotedIdentifier, `"a""b"`}},
+	},
+	"dollar quoted": {
+		sql:      "$$ ' $$$body$ $$ $body$",
+		expected: []Token{{TokenString, "$$ ' $$"}, {TokenString, "$body$ $$ $body$"}},
+	},
+	"dollar in identifier": {
+		sql:      "a$b$ $1",
+		expected: []Token{{TokenIdentifier, "a$b$"}, {TokenWhitespace, " "}, {TokenOther, "$"}, {TokenOther, "1"}},
+	},
+	"comments": {
+		sql:      "-- a 'b\n/* c /* d */ ' */x",
+		expected: []Token{{TokenComment, "-- a 'b"}, {TokenWhitespace, "\n"}, {TokenComment, "/* c /* d */ ' */"}, {TokenIdentifier, "x"}},
+	},
+	"unterminated": {
+		sql:      "'a /* b",
+		expected: []Token{{TokenString, "'a /* b"}},
+	},
+}
+
+func TestLex(t *testing.T) {
+	for name, test := range testCasesLex {
+		tokens := Lex(test.sql)
+		if !reflect.DeepEqual(tokens, test.expected) {
+			t.Errorf("Test: '%s' FAILED : expected %v, got %v", name, test.expected, tokens)
+		}
+		var sb strings.Builder
+		for _, token := range tokens {
+			sb.WriteString(token.Value)
+		}
+		if sb.String() != test.sql {
+			t.Errorf("Test: '%s' FAILED : tokens do not reproduce the sql: %s", name, sb.String())
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package queryparams
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/query/querylexer"
+)
+
+var paramNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// Params is a set of named client side query parameters
+// parameters are referenced in a query as :name, and are passed to the database as bind parameters
+type Params struct {
+	values map[string]string
+}
+
+// New creates an empty Params
+func New() *Params {
+	return &Params{values: make(map[string]string)}
+}
+
+// ParseArgs creates a Params from a list of name=value strings
+func ParseArgs(args []string) (*Params, error) {
+	res := New()
+	for _, arg := range args {
+		name, value, found := strings.Cut(arg, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid param '%s' - params must be in the format name=value", arg)
+		}
+		if err := res.Set(strings.TrimSpace(name), value); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Set sets the value of the named parameter
+func (p *Params) Set(name, value string) error {
+	if !paramNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid param name '%s' - names must start with a letter or underscore and contain only letters, digits and underscores", name)
+	}
+	p.values[name] = value
+	return nil
+}
+
+// Unset removes the named parameter, returning whether it was set
+func (p *Params) Unset(name string) bool {
+	_, ok := p.values[name]
+	delete(p.values, name)
+	return ok
+}
+
+// Get returns the value of the named parameter
+func (p *Params) Get(name string) (string, bool) {
+	value, ok := p.values[name]
+	return value, ok
+}
+
+// Names returns the sorted names of all parameters
+func (p *Params) Names() []string {
+	names := make([]string, 0, len(p.values))
+	for name := range p.values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Empty returns whether there are no parameters set
+func (p *Params) Empty() bool {
+	return p == nil || len(p.values) == 0
+}
+
+// Substitute replaces each :name reference to a parameter in the sql with a positional bind parameter,
+// appending the parameter values to args
+// references inside string literals, quoted identifiers, dollar quoted strings and comments are ignored,
+// as are type casts (::) and references to parameters which are not set
+func (p *Params) Substitute(sql string, args []any) (string, []any) {
+	if p.Empty() {
+		return sql, args
+	}
+
+	var sb strings.Builder
+	// the position of each parameter which has been added to the args
+	positions := make(map[string]int)
+
+	tokens := querylexer.Lex(sql)
+	for i := 0; i < len(tokens); i++ {
+		token := tokens[i]
+		// a reference is a ':' immediately followed by the parameter name
+		if token.Type == querylexer.TokenOther && token.Value == ":" && i+1 < len(tokens) && tokens[i+1].Type == querylexer.TokenIdentifier {
+			name := tokens[i+1].Value
+			if value, ok := p.values[name]; ok {
+				position, ok := positions[name]
+				if !ok {
+					args = append(args, value)
+					position = len(args)
+					positions[name] = position
+				}
+				sb.WriteString(fmt.Sprintf("$%d", position))
+				i++
+				continue
+			}
+		}
+		sb.WriteString(token.Value)
+	}
+	return sb.String(), args
+}
@@ -0,0 +1,109 @@
+package queryparams
+
+import (
+	"reflect"
+	"testing"
+)
+
+type substituteTest struct {
+	sql          string
+	args         []any
+	expectedSQL  string
+	expectedArgs []any
+}
+
+var testCasesSubstitute = map[string]substituteTest{
+	"no references": {
+		sql:         "select 1",
+		expectedSQL: "select 1",
+	},
+	"single reference": {
+		sql:          "select * from aws_s3_bucket where region = :region",
+		expectedSQL:  "select * from aws_s3_bucket where region = $1",
+		expectedArgs: []any{"us-east-1"},
+	},
+	"repeated reference": {
+		sql:          "select :region, :name, :region",
+		expectedSQL:  "select $1, $2, $1",
+		expectedArgs: []any{"us-east-1", "foo"},
+	},
+	"existing args": {
+		sql:          "select $1, :name",
+		args:         []any{"a"},
+		expectedSQL:  "select $1, $2",
+		expectedArgs: []any{"a", "foo"},
+	},
+	"unknown reference": {
+		sql:         "select :unknown",
+		expectedSQL: "select :unknown",
+	},
+	"cast": {
+		sql:          "select :name::text, '1'::int",
+		expectedSQL:  "select $1::text, '1'::int",
+		expectedArgs: []any{"foo"},
+	},
+	"quoted": {
+		sql:          `select ':name', ":name", 'it''s :name', :name`,
+		expectedSQL:  `select ':name', ":name", 'it''s :name', $1`,
+		expectedArgs: []any{"foo"},
+	},
+	"escape string": {
+		sql:          `select E'it\'s :name', e'\\', :name`,
+		expectedSQL:  `select E'it\'s :name', e'\\', $1`,
+		expectedArgs: []any{"foo"},
+	},
+	"identifier prefix": {
+		sql:          "select :name, x:name, :names",
+		expectedSQL:  "select $1, x$1, :names",
+		expectedArgs: []any{"foo"},
+	},
+	"comments": {
+		sql:          "select :name -- :region\n/* :region */ , :region",
+		expectedSQL:  "select $1 -- :region\n/* :region */ , $2",
+		expectedArgs: []any{"foo", "us-east-1"},
+	},
+	"dollar quoted": {
+		sql:          "select $$ :name $$, $body$ :name $body$, :name",
+		expectedSQL:  "select $$ :name $$, $body$ :name $body$, $1",
+		expectedArgs: []any{"foo"},
+	},
+	"unterminated string": {
+		sql:         "select ':name",
+		expectedSQL: "select ':name",
+	},
+}
+
+func TestSubstitute(t *testing.T) {
+	params, err := ParseArgs([]string{"region=us-east-1", "name=foo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, test := range testCasesSubstitute {
+		sql, args := params.Substitute(test.sql, test.args)
+		if sql != test.expectedSQL {
+			t.Errorf("Test: '%s' FAILED : expected sql:\n%s\n\ngot:\n%s", name, test.expectedSQL, sql)
+		}
+		if len(args) != 0 || len(test.expectedArgs) != 0 {
+			if !reflect.DeepEqual(args, test.expectedArgs) {
+				t.Errorf("Test: '%s' FAILED : expected args %v, got %v", name, test.expectedArgs, args)
+			}
+		}
+	}
+}
+
+func TestParseArgs(t *testing.T) {
+	params, err := ParseArgs([]string{"a=1", "b=x=y", "c="})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{"a": "1", "b": "x=y", "c": ""}
+	if !reflect.DeepEqual(params.values, expected) {
+		t.Errorf("expected %v, got %v", expected, params.values)
+	}
+
+	for _, invalid := range []string{"a", "1a=1", "a b=1"} {
+		if _, err := ParseArgs([]string{invalid}); err == nil {
+			t.Errorf("expected error parsing '%s'", invalid)
+		}
+	}
+}
@@ -1,5 +1,10 @@
 package queryresult
 
+import (
+	"fmt"
+	"strings"
+)
+
 type TimingResult struct {
 	DurationMs          int64              `json:"duration_ms"`
 	Scans               []*ScanMetadataRow `json:"scans"`
@@ -55,8 +60,160 @@ func (r *Result) StreamError(err error) {
 	*r.RowChan <- &RowResult{Error: err}
 }
 
+// ResultSummary is the row count and row error of a result returned by Result.WithSummary
+// it is populated as the rows are read, so must only be read once the result has been fully read
+type ResultSummary struct {
+	RowCount int
+	Error    error
+}
+
+// WithSummary returns a Result which streams the rows of this result, and a ResultSummary
+// which is populated with the number of rows read and the row error (if any)
+func (r *Result) WithSummary() (*Result, *ResultSummary) {
+	res := NewResult(r.Cols)
+	res.TimingResult = r.TimingResult
+	summary := &ResultSummary{}
+	go func() {
+		defer res.Close()
+		done := false
+		for row := range *r.RowChan {
+			// once the reader has stopped (on a nil row or an error) just drain the source
+			if done {
+				continue
+			}
+			switch {
+			case row == nil:
+				done = true
+			case row.Error != nil:
+				summary.Error = row.Error
+				done = true
+			default:
+				summary.RowCount++
+			}
+			*res.RowChan <- row
+		}
+	}()
+	return res, summary
+}
+
+// ResultRecording is the rows of a result recorded by Result.Record
+// it is populated as the rows are read, so must only be read once the result has been fully read
+type ResultRecording struct {
+	Result *BufferedResult
+	// set if all rows of the result were recorded
+	Complete bool
+}
+
+// Record returns a Result which streams the rows of this result, and a ResultRecording which records
+// up to maxRows rows - if the result has more rows than this, or returns a row error, the recording is incomplete
+func (r *Result) Record(maxRows int) (*Result, *ResultRecording) {
+	res := NewResult(r.Cols)
+	res.TimingResult = r.TimingResult
+	recording := &ResultRecording{Result: &BufferedResult{Cols: r.Cols}}
+	go func() {
+		defer res.Close()
+		done, overflow := false, false
+		for row := range *r.RowChan {
+			// once the reader has stopped (on a nil row or an error) just drain the source
+			if done {
+				continue
+			}
+			switch {
+			case row == nil:
+				recording.Complete = !overflow
+				done = true
+			case row.Error != nil:
+				done = true
+			case overflow:
+				// no longer recording
+			case len(recording.Result.Rows) >= maxRows:
+				// stop recording (and release the recorded rows), but keep streaming
+				overflow = true
+				recording.Result.Rows = nil
+			default:
+				recording.Result.Rows = append(recording.Result.Rows, row)
+			}
+			*res.RowChan <- row
+		}
+		// the source may be closed without a nil row being sent
+		if !done {
+			recording.Complete = !overflow
+		}
+	}()
+	return res, recording
+}
+
 type SyncQueryResult struct {
 	Rows         []interface{}
 	Cols         []*ColumnDef
 	TimingResult *TimingResult
 }
+
+// BufferedResult is a fully read query result, which may be streamed any number of times
+// this is used when a result must be both displayed and exported
+type BufferedResult struct {
+	Cols         []*ColumnDef
+	Rows         []*RowResult
+	TimingResult *TimingResult
+}
+
+// Buffer reads all rows of the result (which must not have been read) into a BufferedResult
+func (r *Result) Buffer() *BufferedResult {
+	res := &BufferedResult{Cols: r.Cols}
+	for row := range *r.RowChan {
+		if row != nil {
+			res.Rows = append(res.Rows, row)
+		}
+	}
+	// the timing result is sent before the row channel is closed - if timing is disabled, there will be none
+	select {
+	case res.TimingResult = <-r.TimingResult:
+	default:
+	}
+	return res
+}
+
+// NewResult returns a new Result which streams the buffered rows
+func (b *BufferedResult) NewResult() *Result {
+	res := NewResult(b.Cols)
+	go func() {
+		for _, row := range b.Rows {
+			*res.RowChan <- row
+		}
+		if b.TimingResult != nil {
+			res.TimingResult <- b.TimingResult
+		}
+		res.Close()
+	}()
+	return res
+}
+
+// SelectColumns returns a BufferedResult containing only the given columns of this result, in the given order
+func (b *BufferedResult) SelectColumns(names []string) (*BufferedResult, error) {
+	var indexes []int
+	res := &BufferedResult{TimingResult: b.TimingResult}
+	for _, name := range names {
+		idx := -1
+		for i, col := range b.Cols {
+			if strings.EqualFold(col.Name, name) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return nil, fmt.Errorf("column '%s' not found", name)
+		}
+		indexes = append(indexes, idx)
+		res.Cols = append(res.Cols, b.Cols[idx])
+	}
+
+	res.Rows = make([]*RowResult, len(b.Rows))
+	for i, row := range b.Rows {
+		data := make([]interface{}, len(indexes))
+		for j, idx := range indexes {
+			data[j] = row.Data[idx]
+		}
+		res.Rows[i] = &RowResult{Data: data}
+	}
+	return res, nil
+}
@@ -0,0 +1,216 @@
+package queryresult
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+var testCols = []*ColumnDef{{Name: "id", DataType: "INT8"}, {Name: "Name", DataType: "TEXT"}, {Name: "region", DataType: "TEXT"}}
+
+// a nil entry is sent as a nil row (the end of the result) and an error entry as a row error
+func newTestResult(rows []interface{}) *Result {
+	res := NewResult(testCols)
+	go func() {
+		for _, row := range rows {
+			switch r := row.(type) {
+			case nil:
+				*res.RowChan <- nil
+			case error:
+				res.StreamError(r)
+			default:
+				res.StreamRow([]interface{}{r})
+			}
+		}
+		res.Close()
+	}()
+	return res
+}
+
+// readTestResult reads all rows of the result, returning them in the form they were sent by newTestResult
+func readTestResult(res *Result) []interface{} {
+	var rows []interface{}
+	for row := range *res.RowChan {
+		switch {
+		case row == nil:
+			rows = append(rows, nil)
+		case row.Error != nil:
+			rows = append(rows, row.Error)
+		default:
+			rows = append(rows, row.Data[0])
+		}
+	}
+	return rows
+}
+
+type recordTest struct {
+	rows     []interface{}
+	maxRows  int
+	expected []interface{}
+	// the rows which should be streamed, if different to the source rows
+	expectedStreamed []interface{}
+	complete         bool
+}
+
+var testRowError = errors.New("row error")
+
+var testCasesRecord = map[string]recordTest{
+	"under limit": {
+		rows:     []interface{}{1, 2, 3, nil},
+		maxRows:  5,
+		expected: []interface{}{1, 2, 3},
+		complete: true,
+	},
+	"at limit": {
+		rows:     []interface{}{1, 2, 3, nil},
+		maxRows:  3,
+		expected: []interface{}{1, 2, 3},
+		complete: true,
+	},
+	"over limit": {
+		rows:     []interface{}{1, 2, 3, 4, nil},
+		maxRows:  3,
+		expected: nil,
+		complete: false,
+	},
+	"empty": {
+		rows:     []interface{}{nil},
+		maxRows:  3,
+		expected: nil,
+		complete: true,
+	},
+	"row error": {
+		rows:     []interface{}{1, 2, testRowError},
+		maxRows:  5,
+		expected: []interface{}{1, 2},
+		complete: false,
+	},
+	"rows after row error are drained": {
+		rows:             []interface{}{1, testRowError, 2, nil},
+		maxRows:          5,
+		expected:         []interface{}{1},
+		expectedStreamed: []interface{}{1, testRowError},
+		complete:         false,
+	},
+	"rows after nil row are drained": {
+		rows:             []interface{}{1, 2, nil, 3},
+		maxRows:          5,
+		expected:         []interface{}{1, 2},
+		expectedStreamed: []interface{}{1, 2, nil},
+		complete:         true,
+	},
+	"closed without nil row": {
+		rows:     []interface{}{1, 2},
+		maxRows:  5,
+		expected: []interface{}{1, 2},
+		complete: true,
+	},
+	"closed without nil row over limit": {
+		rows:     []interface{}{1, 2, 3},
+		maxRows:  2,
+		expected: nil,
+		complete: false,
+	},
+}
+
+func TestRecord(t *testing.T) {
+	for name, test := range testCasesRecord {
+		res, recording := newTestResult(test.rows).Record(test.maxRows)
+
+		expectedStreamed := test.rows
+		if test.expectedStreamed != nil {
+			expectedStreamed = test.expectedStreamed
+		}
+		// the recording is only valid once the result has been fully read
+		if streamed := readTestResult(res); !reflect.DeepEqual(streamed, expectedStreamed) {
+			t.Errorf("Test: '%s'' FAILED : expected %v to be streamed, got %v", name, expectedStreamed, streamed)
+		}
+
+		if recording.Complete != test.complete {
+			t.Errorf("Test: '%s'' FAILED : expected complete %v, got %v", name, test.complete, recording.Complete)
+		}
+		var recorded []interface{}
+		for _, row := range recording.Result.Rows {
+			recorded = append(recorded, row.Data[0])
+		}
+		if !reflect.DeepEqual(recorded, test.expected) {
+			t.Errorf("Test: '%s'' FAILED : expected %v to be recorded, got %v", name, test.expected, recorded)
+		}
+	}
+}
+
+type selectColumnsTest struct {
+	columns     []string
+	expected    string
+	expectError bool
+}
+
+var testCasesSelectColumns = map[string]selectColumnsTest{
+	"single column": {
+		columns:  []string{"region"},
+		expected: "[region] [[us-east-1] [eu-west-2]]",
+	},
+	"reordered": {
+		columns:  []string{"region", "id"},
+		expected: "[region id] [[us-east-1 1] [eu-west-2 2]]",
+	},
+	"repeated": {
+		columns:  []string{"id", "id"},
+		expected: "[id id] [[1 1] [2 2]]",
+	},
+	"case insensitive": {
+		columns:  []string{"NAME", "Region"},
+		expected: "[Name region] [[a us-east-1] [b eu-west-2]]",
+	},
+	"unknown column": {
+		columns:     []string{"id", "arn"},
+		expectError: true,
+	},
+}
+
+func TestSelectColumns(t *testing.T) {
+	timing := &TimingResult{RowsReturned: 2}
+	result := &BufferedResult{
+		Cols: testCols,
+		Rows: []*RowResult{
+			{Data: []interface{}{1, "a", "us-east-1"}},
+			{Data: []interface{}{2, "b", "eu-west-2"}},
+		},
+		TimingResult: timing,
+	}
+
+	for name, test := range testCasesSelectColumns {
+		res, err := result.SelectColumns(test.columns)
+		if test.expectError {
+			if err == nil {
+				t.Errorf("Test: '%s'' FAILED : expected an error", name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Test: '%s'' FAILED : unexpected error: %s", name, err.Error())
+			continue
+		}
+
+		var cols []string
+		for _, col := range res.Cols {
+			cols = append(cols, col.Name)
+		}
+		var rows [][]interface{}
+		for _, row := range res.Rows {
+			rows = append(rows, row.Data)
+		}
+		if actual := fmt.Sprintf("%v %v", cols, rows); actual != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %s, got %s", name, test.expected, actual)
+		}
+		if res.TimingResult != timing {
+			t.Errorf("Test: '%s'' FAILED : expected the timing result to be kept", name)
+		}
+	}
+
+	// the source result must not be modified
+	if len(result.Rows[0].Data) != 3 {
+		t.Errorf("expected the source rows to be unchanged, got %v", result.Rows[0].Data)
+	}
+}
@@ -0,0 +1,39 @@
+package queryscript
+
+import (
+	"strings"
+
+	"github.com/turbot/steampipe/pkg/query/querylexer"
+)
+
+// SplitStatements splits a SQL script into its statements, using the semicolons which terminate them
+//
+// semicolons inside string literals, quoted identifiers, comments and dollar quoted strings (e.g. function bodies)
+// do not terminate a statement - statements are returned without their terminating semicolon, and statements
+// which only contain whitespace or comments are removed
+func SplitStatements(script string) []string {
+	var res []string
+	var statement strings.Builder
+	// whether the current statement contains anything other than whitespace and comments
+	hasContent := false
+	addStatement := func() {
+		if hasContent {
+			res = append(res, strings.TrimSpace(statement.String()))
+		}
+		statement.Reset()
+		hasContent = false
+	}
+
+	for _, token := range querylexer.Lex(script) {
+		if token.Type == querylexer.TokenOther && token.Value == ";" {
+			addStatement()
+			continue
+		}
+		statement.WriteString(token.Value)
+		if !token.IsSpaceOrComment() {
+			hasContent = true
+		}
+	}
+	addStatement()
+	return res
+}
@@ -0,0 +1,38 @@
+package queryscript
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	cases := map[string][]string{
+		`select 1`:                                     {`select 1`},
+		`select 1;`:                                    {`select 1`},
+		"select 1;\nselect 2;\n":                       {`select 1`, `select 2`},
+		" ; ;\n-- just a comment\n;":                   nil,
+		`select ';' as a; select 2`:                    {`select ';' as a`, `select 2`},
+		`select 'it''s;' ; select 2`:                   {`select 'it''s;'`, `select 2`},
+		`select "a;b" from t`:                          {`select "a;b" from t`},
+		`select E'it\'s;'; select 2`:                   {`select E'it\'s;'`, `select 2`},
+		"select 1 -- a; comment\n; select 2":           {"select 1 -- a; comment", `select 2`},
+		`select /* a; /* nested; */ b; */ 1; select 2`: {`select /* a; /* nested; */ b; */ 1`, `select 2`},
+		"create function f() returns int as $$ select 1; $$ language sql; select f()": {
+			"create function f() returns int as $$ select 1; $$ language sql",
+			"select f()",
+		},
+		`select $body$ a; $x$ b; $body$; select $1`: {`select $body$ a; $x$ b; $body$`, `select $1`},
+		"create temp table x as select 1 as a;\n\n-- use it\nselect * from x;": {
+			"create temp table x as select 1 as a",
+			"-- use it\nselect * from x",
+		},
+		`select 'unterminated; select 2`: {`select 'unterminated; select 2`},
+	}
+
+	for input, expected := range cases {
+		actual := SplitStatements(input)
+		if !reflect.DeepEqual(actual, expected) {
+			t.Errorf("%q: expected %q, got %q", input, expected, actual)
+		}
+	}
+}
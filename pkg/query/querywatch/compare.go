@@ -0,0 +1,126 @@
+package querywatch
+
+import (
+	"fmt"
+
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+// RowChange is how a row of a watched query result has changed since the previous run
+type RowChange int
+
+const (
+	RowUnchanged RowChange = iota
+	RowAdded
+	RowChanged
+	RowRemoved
+)
+
+// Comparison is the result of comparing a query result with the result of the previous run
+type Comparison struct {
+	// the rows of the current result, followed by the rows which have been removed since the previous run
+	Result *queryresult.BufferedResult
+	// the change for each row of Result
+	Changes []RowChange
+}
+
+// Count returns the number of rows with the given change
+func (c *Comparison) Count(change RowChange) int {
+	count := 0
+	for _, rowChange := range c.Changes {
+		if rowChange == change {
+			count++
+		}
+	}
+	return count
+}
+
+// Compare compares a query result with the result of the previous run
+//
+// rows are matched using the value of their first column if this is unique in both results, so a row whose other
+// values differ is reported as changed - otherwise rows are matched using all of their values, and so are only
+// ever reported as added or removed
+//
+// if there is no previous result, or the columns have changed, all rows are reported as unchanged
+func Compare(previous, current *queryresult.BufferedResult) *Comparison {
+	res := &Comparison{
+		Result:  &queryresult.BufferedResult{Cols: current.Cols, Rows: current.Rows, TimingResult: current.TimingResult},
+		Changes: make([]RowChange, len(current.Rows)),
+	}
+	if previous == nil || !sameColumns(previous, current) {
+		return res
+	}
+
+	keyFunc := rowValue
+	if len(current.Cols) > 1 && hasUniqueKeys(previous) && hasUniqueKeys(current) {
+		keyFunc = rowKey
+	}
+
+	// build a lookup of the previous rows - keyed rows are unique, but full row values may be repeated
+	previousRows := make(map[string][]*queryresult.RowResult)
+	for _, row := range previous.Rows {
+		key := keyFunc(row)
+		previousRows[key] = append(previousRows[key], row)
+	}
+
+	for i, row := range current.Rows {
+		key := keyFunc(row)
+		matches := previousRows[key]
+		if len(matches) == 0 {
+			res.Changes[i] = RowAdded
+			continue
+		}
+		if rowValue(matches[0]) != rowValue(row) {
+			res.Changes[i] = RowChanged
+		}
+		previousRows[key] = matches[1:]
+	}
+
+	// add any previous rows which were not matched, in their original order
+	for _, row := range previous.Rows {
+		key := keyFunc(row)
+		if matches := previousRows[key]; len(matches) > 0 && matches[0] == row {
+			res.Result.Rows = append(res.Result.Rows, row)
+			res.Changes = append(res.Changes, RowRemoved)
+			previousRows[key] = matches[1:]
+		}
+	}
+	return res
+}
+
+func sameColumns(previous, current *queryresult.BufferedResult) bool {
+	if len(previous.Cols) != len(current.Cols) {
+		return false
+	}
+	for i, col := range current.Cols {
+		if previous.Cols[i].Name != col.Name {
+			return false
+		}
+	}
+	return true
+}
+
+func hasUniqueKeys(result *queryresult.BufferedResult) bool {
+	keys := make(map[string]struct{}, len(result.Rows))
+	for _, row := range result.Rows {
+		key := rowKey(row)
+		if _, exists := keys[key]; exists {
+			return false
+		}
+		keys[key] = struct{}{}
+	}
+	return true
+}
+
+// rowKey returns the value of the first column of the row
+func rowKey(row *queryresult.RowResult) string {
+	if len(row.Data) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%v", row.Data[0])
+}
+
+// rowValue returns the values of all columns of the row
+func rowValue(row *queryresult.RowResult) string {
+	return fmt.Sprintf("%v", row.Data)
+}
@@ -0,0 +1,72 @@
+package querywatch
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+)
+
+func newResult(cols []string, rows ...[]interface{}) *queryresult.BufferedResult {
+	res := &queryresult.BufferedResult{}
+	for _, col := range cols {
+		res.Cols = append(res.Cols, &queryresult.ColumnDef{Name: col})
+	}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, &queryresult.RowResult{Data: row})
+	}
+	return res
+}
+
+func TestCompare(t *testing.T) {
+	cases := map[string]struct {
+		previous        *queryresult.BufferedResult
+		current         *queryresult.BufferedResult
+		expectedChanges []RowChange
+		expectedRows    []interface{}
+	}{
+		"first run": {
+			current:         newResult([]string{"id", "state"}, []interface{}{"a", "running"}),
+			expectedChanges: []RowChange{RowUnchanged},
+			expectedRows:    []interface{}{"a"},
+		},
+		"keyed by first column": {
+			previous:        newResult([]string{"id", "state"}, []interface{}{"a", "running"}, []interface{}{"b", "running"}, []interface{}{"c", "running"}),
+			current:         newResult([]string{"id", "state"}, []interface{}{"a", "running"}, []interface{}{"c", "stopped"}, []interface{}{"d", "running"}),
+			expectedChanges: []RowChange{RowUnchanged, RowChanged, RowAdded, RowRemoved},
+			expectedRows:    []interface{}{"a", "c", "d", "b"},
+		},
+		"duplicate keys compare whole rows": {
+			previous:        newResult([]string{"region", "count"}, []interface{}{"us-east-1", 1}, []interface{}{"us-east-1", 2}),
+			current:         newResult([]string{"region", "count"}, []interface{}{"us-east-1", 2}, []interface{}{"us-east-1", 3}),
+			expectedChanges: []RowChange{RowUnchanged, RowAdded, RowRemoved},
+			expectedRows:    []interface{}{"us-east-1", "us-east-1", "us-east-1"},
+		},
+		"repeated rows": {
+			previous:        newResult([]string{"name"}, []interface{}{"a"}, []interface{}{"a"}, []interface{}{"a"}),
+			current:         newResult([]string{"name"}, []interface{}{"a"}),
+			expectedChanges: []RowChange{RowUnchanged, RowRemoved, RowRemoved},
+			expectedRows:    []interface{}{"a", "a", "a"},
+		},
+		"columns changed": {
+			previous:        newResult([]string{"id"}, []interface{}{"a"}),
+			current:         newResult([]string{"name"}, []interface{}{"b"}),
+			expectedChanges: []RowChange{RowUnchanged},
+			expectedRows:    []interface{}{"b"},
+		},
+	}
+
+	for name, test := range cases {
+		actual := Compare(test.previous, test.current)
+		if !reflect.DeepEqual(actual.Changes, test.expectedChanges) {
+			t.Errorf("%s: expected changes %v, got %v", name, test.expectedChanges, actual.Changes)
+		}
+		var rows []interface{}
+		for _, row := range actual.Result.Rows {
+			rows = append(rows, row.Data[0])
+		}
+		if !reflect.DeepEqual(rows, test.expectedRows) {
+			t.Errorf("%s: expected rows %v, got %v", name, test.expectedRows, rows)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package querywatch
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/jedib0t/go-pretty/v6/text"
+	"github.com/spf13/viper"
+	"github.com/turbot/steampipe/pkg/constants"
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/display"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/query/queryresult"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+	"github.com/turbot/steampipe/pkg/utils"
+)
+
+// the maximum length of the query shown in the watch header
+const maxHeaderQueryLength = 80
+
+var rowChangeColors = map[RowChange]text.Colors{
+	RowAdded:   {text.FgGreen},
+	RowChanged: {text.FgYellow},
+	RowRemoved: {text.FgRed, text.CrossedOut},
+}
+
+// ParseInterval parses a watch interval, e.g. 30s or 5m
+func ParseInterval(interval string) (time.Duration, error) {
+	res, err := time.ParseDuration(interval)
+	if err != nil {
+		return 0, fmt.Errorf("invalid watch interval '%s' - must be a duration such as 30s or 5m", interval)
+	}
+	if res < constants.MinWatchInterval {
+		return 0, fmt.Errorf("watch interval must be at least %s", constants.MinWatchInterval)
+	}
+	return res, nil
+}
+
+// Watch runs the query every interval until the context is cancelled, redrawing the result in place
+// and highlighting the rows which have been added, removed or changed since the previous run
+//
+// unless caching has been explicitly enabled, the cache is disabled while watching so each run returns fresh data
+func Watch(ctx context.Context, client db_common.Client, resolvedQuery *modconfig.ResolvedQuery, interval time.Duration) error {
+	sessionResult := client.AcquireSession(ctx)
+	if sessionResult.Error != nil {
+		return sessionResult.Error
+	}
+	session := sessionResult.Session
+	defer func() {
+		// we need to do this in a closure, otherwise the ctx will be evaluated immediately
+		// and not in call-time
+		session.Close(error_helpers.IsContextCanceled(ctx))
+	}()
+
+	if !cacheExplicitlyEnabled() {
+		conn := session.Connection.Conn()
+		if err := db_common.SetCacheEnabled(ctx, false, conn); err != nil {
+			return err
+		}
+		defer func() {
+			// the watch context will usually have been cancelled, so restore the setting using a new context
+			if err := db_common.SetCacheEnabled(context.Background(), defaultCacheEnabled(client), conn); err != nil {
+				log.Printf("[WARN] failed to restore the cache setting after watching a query: %s", err.Error())
+			}
+		}()
+	}
+
+	var previous *queryresult.BufferedResult
+	for {
+		current, err := runQuery(ctx, client, session, resolvedQuery)
+		if error_helpers.IsContextCanceled(ctx) {
+			return nil
+		}
+
+		var comparison *Comparison
+		if err == nil {
+			comparison = Compare(previous, current)
+			previous = current
+		}
+		render(ctx, resolvedQuery, interval, comparison, err)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// runQuery executes the query in the watch session, and reads the full result
+func runQuery(ctx context.Context, client db_common.Client, session *db_common.DatabaseSession, resolvedQuery *modconfig.ResolvedQuery) (*queryresult.BufferedResult, error) {
+	result, err := client.ExecuteInSession(ctx, session, nil, resolvedQuery.ExecuteSQL, resolvedQuery.Args...)
+	if err != nil {
+		return nil, err
+	}
+	buffered := result.Buffer()
+	for _, row := range buffered.Rows {
+		if row.Error != nil {
+			return nil, row.Error
+		}
+	}
+	return buffered, nil
+}
+
+// render builds the output for a run and then redraws the screen, so the previous output is replaced in one step
+func render(ctx context.Context, resolvedQuery *modconfig.ResolvedQuery, interval time.Duration, comparison *Comparison, err error) {
+	var b bytes.Buffer
+	b.WriteString(header(resolvedQuery, interval))
+	b.WriteString("\n\n")
+
+	if err != nil {
+		b.WriteString(fmt.Sprintf("%s: %s\n", constants.Red("Error"), error_helpers.DecodePgError(err).Error()))
+	} else {
+		rowColors := func(rowIdx int) text.Colors {
+			return rowChangeColors[comparison.Changes[rowIdx]]
+		}
+		display.ShowOutput(ctx, comparison.Result.NewResult(),
+			display.WithOutputWriter(&b),
+			display.WithTimingDisabled(),
+			display.WithRowColors(rowColors))
+		b.WriteString(fmt.Sprintf("\n%s\n", summary(comparison)))
+	}
+
+	display.ClearScreen()
+	fmt.Print(b.String())
+}
+
+func header(resolvedQuery *modconfig.ResolvedQuery, interval time.Duration) string {
+	// show the first line of the query, truncated to fit on a line
+	query := strings.TrimSpace(resolvedQuery.Name)
+	if idx := strings.Index(query, "\n"); idx != -1 {
+		query = query[:idx] + "…"
+	}
+	if runes := []rune(query); len(runes) > maxHeaderQueryLength {
+		query = string(runes[:maxHeaderQueryLength]) + "…"
+	}
+	return fmt.Sprintf("Every %s: %s    %s", interval, query, time.Now().Format(time.RFC1123))
+}
+
+func summary(comparison *Comparison) string {
+	rowCount := len(comparison.Changes) - comparison.Count(RowRemoved)
+	return fmt.Sprintf("%d %s: %d added, %d removed, %d changed. Press Ctrl+C to stop watching.",
+		rowCount,
+		utils.Pluralize("row", rowCount),
+		comparison.Count(RowAdded),
+		comparison.Count(RowRemoved),
+		comparison.Count(RowChanged))
+}
+
+// cacheExplicitlyEnabled returns whether caching has been enabled using --cache, the workspace options or .cache
+func cacheExplicitlyEnabled() bool {
+	return viper.IsSet(constants.ArgClientCacheEnabled) && viper.GetBool(constants.ArgClientCacheEnabled)
+}
+
+// defaultCacheEnabled returns the cache setting a session would have if it had not been changed by the watch
+func defaultCacheEnabled(client db_common.Client) bool {
+	if viper.IsSet(constants.ArgClientCacheEnabled) {
+		return viper.GetBool(constants.ArgClientCacheEnabled)
+	}
+	if serverSettings := client.ServerSettings(); serverSettings != nil {
+		return serverSettings.CacheEnabled
+	}
+	return true
+}
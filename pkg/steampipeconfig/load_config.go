@@ -290,6 +290,17 @@ func loadConfig(ctx context.Context, configFolder string, steampipeConfig *Steam
 			}
 			steampipeConfig.Connections[connection.Name] = connection
 
+		case modconfig.BlockTypeSchedule:
+			schedule, moreDiags := parse.DecodeSchedule(block)
+			diags = append(diags, moreDiags...)
+			if moreDiags.HasErrors() {
+				continue
+			}
+			// NOTE: this errors if there is a schedule block with a duplicate label
+			if err := steampipeConfig.addSchedule(schedule); err != nil {
+				return error_helpers.NewErrorsAndWarning(err)
+			}
+
 		case modconfig.BlockTypeOptions:
 			// check this options type is permitted based on the options passed in
 			if err := optionsBlockPermitted(block, optionBlockMap, opts); err != nil {
@@ -31,6 +31,7 @@ const (
 	BlockTypeLegacyRequires = "requires"
 	BlockTypeCategory       = "category"
 	BlockTypeWith           = "with"
+	BlockTypeExemption      = "exemption"
 
 	// config blocks
 	BlockTypeRateLimiter      = "limiter"
@@ -38,6 +39,7 @@ const (
 	BlockTypeConnection       = "connection"
 	BlockTypeOptions          = "options"
 	BlockTypeWorkspaceProfile = "workspace"
+	BlockTypeSchedule         = "schedule"
 
 	ResourceTypeSnapshot = "snapshot"
 	AttributeArgs        = "args"
@@ -88,6 +90,7 @@ var ReferenceBlocks = []string{
 	BlockTypeParam,
 	BlockTypeCategory,
 	BlockTypeWith,
+	BlockTypeExemption,
 }
 
 var ValidResourceItemTypes = []string{
@@ -118,6 +121,7 @@ var ValidResourceItemTypes = []string{
 	BlockTypeOptions,
 	BlockTypeWorkspaceProfile,
 	BlockTypeWith,
+	BlockTypeExemption,
 	// local is not an actual block name but is a resource type
 	"local",
 	// references
@@ -0,0 +1,230 @@
+package modconfig
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+	typehelpers "github.com/turbot/go-kit/types"
+	"github.com/turbot/pipe-fittings/hclhelpers"
+	"github.com/turbot/steampipe/pkg/utils"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Exemption is a struct representing the Exemption resource
+// An exemption suppresses the results of the given controls (or the controls of the given benchmarks)
+// for resources matching the resource patterns and dimension values
+type Exemption struct {
+	ResourceWithMetadataImpl
+	ModTreeItemImpl
+
+	// required to allow partial decoding
+	Remain hcl.Body `hcl:",remain" json:"-"`
+
+	// control and benchmark names as NamedItem structs - used to allow setting targets via the 'controls' property
+	ControlNames NamedItemList `cty:"control_names" json:"-"`
+	// the full names of the target controls and benchmarks
+	ControlNameStrings []string `cty:"control_name_strings" json:"controls"`
+	// resource name patterns - '*' matches any sequence of characters, '?' matches a single character
+	Resources []string `cty:"resources" json:"resources,omitempty"`
+	// map of dimension name to value pattern - all dimensions must match
+	Dimensions map[string]string `cty:"dimensions" json:"dimensions,omitempty"`
+	Reason     *string           `cty:"reason" json:"reason"`
+	// the date (YYYY-MM-DD) or time (RFC3339) when the exemption expires
+	Expires *string `cty:"expires" json:"expires,omitempty"`
+
+	expiry           *time.Time
+	resourcePatterns []*regexp.Regexp
+	dimensionValues  map[string]*regexp.Regexp
+}
+
+func NewExemption(block *hcl.Block, mod *Mod, shortName string) HclResource {
+	fullName := fmt.Sprintf("%s.%s.%s", mod.ShortName, block.Type, shortName)
+	exemption := &Exemption{
+		ModTreeItemImpl: ModTreeItemImpl{
+			HclResourceImpl: HclResourceImpl{
+				ShortName:       shortName,
+				FullName:        fullName,
+				UnqualifiedName: fmt.Sprintf("%s.%s", block.Type, shortName),
+				DeclRange:       hclhelpers.BlockRange(block),
+				blockType:       block.Type,
+			},
+			Mod: mod,
+		},
+	}
+	exemption.SetAnonymous(block)
+	return exemption
+}
+
+// OnDecoded implements HclResource
+func (e *Exemption) OnDecoded(block *hcl.Block, _ ResourceMapsProvider) hcl.Diagnostics {
+	var diags hcl.Diagnostics
+	if typehelpers.SafeString(e.Reason) == "" {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("%s must specify a reason", e.Name()),
+			Subject:  &e.DeclRange,
+		})
+	}
+	if e.Expires != nil {
+		expiry, err := parseExemptionExpiry(*e.Expires)
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  fmt.Sprintf("%s has an invalid 'expires' value '%s'", e.Name(), *e.Expires),
+				Detail:   "expires must be a date (YYYY-MM-DD) or an RFC3339 time",
+				Subject:  &e.DeclRange,
+			})
+		}
+		e.expiry = expiry
+	}
+
+	e.resourcePatterns = make([]*regexp.Regexp, len(e.Resources))
+	for i, pattern := range e.Resources {
+		e.resourcePatterns[i] = globToRegexp(pattern)
+	}
+	e.dimensionValues = make(map[string]*regexp.Regexp, len(e.Dimensions))
+	for key, pattern := range e.Dimensions {
+		e.dimensionValues[key] = globToRegexp(pattern)
+	}
+	return diags
+}
+
+// parseExemptionExpiry parses a date or an RFC3339 time
+// a date exemption applies until the end of that day (UTC)
+func parseExemptionExpiry(expires string) (*time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, expires); err == nil {
+		return &t, nil
+	}
+	t, err := time.Parse("2006-01-02", expires)
+	if err != nil {
+		return nil, err
+	}
+	t = t.AddDate(0, 0, 1)
+	return &t, nil
+}
+
+// globToRegexp converts a resource/dimension pattern to an anchored regular expression
+func globToRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, c := range pattern {
+		switch c {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// IsExpired returns whether the exemption has expired at the given time
+func (e *Exemption) IsExpired(now time.Time) bool {
+	return e.expiry != nil && !now.Before(*e.expiry)
+}
+
+// AppliesTo returns whether the given control or benchmark is one of the exemption targets
+func (e *Exemption) AppliesTo(name string) bool {
+	for _, target := range e.ControlNameStrings {
+		if target == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Matches returns whether the resource and dimensions of a control result match the exemption
+// dimensions is a function returning the value of a dimension and whether it exists
+func (e *Exemption) Matches(resource string, dimensions func(key string) (string, bool)) bool {
+	if len(e.resourcePatterns) > 0 {
+		matched := false
+		for _, pattern := range e.resourcePatterns {
+			if pattern.MatchString(resource) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for key, pattern := range e.dimensionValues {
+		value, ok := dimensions(key)
+		if !ok || !pattern.MatchString(value) {
+			return false
+		}
+	}
+	return true
+}
+
+// SetControls sets the full names of the target controls and benchmarks
+func (e *Exemption) SetControls(controls []ModTreeItem) {
+	e.ControlNameStrings = make([]string, len(controls))
+	for i, c := range controls {
+		e.ControlNameStrings[i] = c.Name()
+	}
+}
+
+func (e *Exemption) Equals(other *Exemption) bool {
+	if other == nil {
+		return false
+	}
+
+	return !e.Diff(other).HasChanges()
+}
+
+func (e *Exemption) Diff(other *Exemption) *DashboardTreeItemDiffs {
+	res := &DashboardTreeItemDiffs{
+		Item: e,
+		Name: e.Name(),
+	}
+
+	if !utils.SafeStringsEqual(e.Title, other.Title) {
+		res.AddPropertyDiff("Title")
+	}
+	if !utils.SafeStringsEqual(e.Description, other.Description) {
+		res.AddPropertyDiff("Description")
+	}
+	if !utils.SafeStringsEqual(e.Reason, other.Reason) {
+		res.AddPropertyDiff("Reason")
+	}
+	if !utils.SafeStringsEqual(e.Expires, other.Expires) {
+		res.AddPropertyDiff("Expires")
+	}
+	if strings.Join(e.ControlNameStrings, ",") != strings.Join(other.ControlNameStrings, ",") {
+		res.AddPropertyDiff("Controls")
+	}
+	if strings.Join(e.Resources, ",") != strings.Join(other.Resources, ",") {
+		res.AddPropertyDiff("Resources")
+	}
+	if len(e.Dimensions) != len(other.Dimensions) {
+		res.AddPropertyDiff("Dimensions")
+	} else {
+		for k, v := range e.Dimensions {
+			if otherVal, ok := other.Dimensions[k]; !ok || v != otherVal {
+				res.AddPropertyDiff("Dimensions")
+			}
+		}
+	}
+	if len(e.Tags) != len(other.Tags) {
+		res.AddPropertyDiff("Tags")
+	} else {
+		for k, v := range e.Tags {
+			if otherVal := other.Tags[k]; v != otherVal {
+				res.AddPropertyDiff("Tags")
+			}
+		}
+	}
+	return res
+}
+
+// CtyValue implements CtyValueProvider
+func (e *Exemption) CtyValue() (cty.Value, error) {
+	return GetCtyValue(e)
+}
@@ -0,0 +1,99 @@
+package modconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+type exemptionMatchTest struct {
+	resources  []string
+	dimensions map[string]string
+	resource   string
+	rowDims    map[string]string
+	expected   bool
+}
+
+var exemptionMatchTestCases = map[string]exemptionMatchTest{
+	"no patterns": {
+		resource: "arn:aws:s3:::bucket",
+		expected: true,
+	},
+	"exact resource": {
+		resources: []string{"arn:aws:s3:::bucket"},
+		resource:  "arn:aws:s3:::bucket",
+		expected:  true,
+	},
+	"wildcard resource": {
+		resources: []string{"arn:aws:s3:::*-logs"},
+		resource:  "arn:aws:s3:::prod/access-logs",
+		expected:  true,
+	},
+	"resource not matched": {
+		resources: []string{"arn:aws:s3:::*-logs"},
+		resource:  "arn:aws:s3:::bucket",
+		expected:  false,
+	},
+	"single character wildcard": {
+		resources: []string{"i-?"},
+		resource:  "i-1",
+		expected:  true,
+	},
+	"regex characters are literal": {
+		resources: []string{"a.b"},
+		resource:  "axb",
+		expected:  false,
+	},
+	"dimension matched": {
+		dimensions: map[string]string{"region": "us-*"},
+		resource:   "r",
+		rowDims:    map[string]string{"region": "us-east-1"},
+		expected:   true,
+	},
+	"dimension not matched": {
+		dimensions: map[string]string{"region": "us-*"},
+		resource:   "r",
+		rowDims:    map[string]string{"region": "eu-west-1"},
+		expected:   false,
+	},
+	"dimension missing": {
+		dimensions: map[string]string{"account_id": "123"},
+		resource:   "r",
+		rowDims:    map[string]string{"region": "us-east-1"},
+		expected:   false,
+	},
+}
+
+func TestExemptionMatches(t *testing.T) {
+	reason := "accepted"
+	for name, test := range exemptionMatchTestCases {
+		exemption := &Exemption{Resources: test.resources, Dimensions: test.dimensions, Reason: &reason}
+		if diags := exemption.OnDecoded(&hcl.Block{}, nil); diags.HasErrors() {
+			t.Fatalf("Test: '%s'' FAILED : %s", name, diags.Error())
+		}
+		getDimension := func(key string) (string, bool) {
+			value, ok := test.rowDims[key]
+			return value, ok
+		}
+		if actual := exemption.Matches(test.resource, getDimension); actual != test.expected {
+			t.Errorf("Test: '%s'' FAILED : expected %v, got %v", name, test.expected, actual)
+		}
+	}
+}
+
+func TestExemptionIsExpired(t *testing.T) {
+	reason := "accepted"
+	expires := "2024-06-30"
+	exemption := &Exemption{Reason: &reason, Expires: &expires}
+	if diags := exemption.OnDecoded(&hcl.Block{}, nil); diags.HasErrors() {
+		t.Fatal(diags.Error())
+	}
+	// a date expiry applies until the end of that day
+	if exemption.IsExpired(time.Date(2024, 6, 30, 23, 59, 0, 0, time.UTC)) {
+		t.Errorf("exemption should not have expired on the expiry date")
+	}
+	if !exemption.IsExpired(time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("exemption should have expired the day after the expiry date")
+	}
+}
@@ -32,6 +32,7 @@ type ResourceMaps struct {
 	DashboardTables       map[string]*DashboardTable
 	DashboardTexts        map[string]*DashboardText
 	DashboardNodes        map[string]*DashboardNode
+	Exemptions            map[string]*Exemption
 	GlobalDashboardInputs map[string]*DashboardInput
 	Locals                map[string]*Local
 	Mods                  map[string]*Mod
@@ -73,6 +74,7 @@ func emptyModResources() *ResourceMaps {
 		DashboardTexts:        make(map[string]*DashboardText),
 		DashboardNodes:        make(map[string]*DashboardNode),
 		DashboardCategories:   make(map[string]*DashboardCategory),
+		Exemptions:            make(map[string]*Exemption),
 		GlobalDashboardInputs: make(map[string]*DashboardInput),
 		Locals:                make(map[string]*Local),
 		Mods:                  make(map[string]*Mod),
@@ -362,6 +364,19 @@ func (m *ResourceMaps) Equals(other *ResourceMaps) bool {
 		}
 	}
 
+	for name, exemption := range m.Exemptions {
+		if otherExemption, ok := other.Exemptions[name]; !ok {
+			return false
+		} else if !exemption.Equals(otherExemption) {
+			return false
+		}
+	}
+	for name := range other.Exemptions {
+		if _, ok := m.Exemptions[name]; !ok {
+			return false
+		}
+	}
+
 	for name, reference := range m.References {
 		if otherReference, ok := other.References[name]; !ok {
 			return false
@@ -412,6 +427,8 @@ func (m *ResourceMaps) GetResource(parsedName *ParsedResourceName) (resource Hcl
 		resource, found = m.DashboardContainers[longName]
 	case BlockTypeEdge:
 		resource, found = m.DashboardEdges[longName]
+	case BlockTypeExemption:
+		resource, found = m.Exemptions[longName]
 	case BlockTypeFlow:
 		resource, found = m.DashboardFlows[longName]
 	case BlockTypeGraph:
@@ -547,6 +564,7 @@ func (m *ResourceMaps) Empty() bool {
 		len(m.DashboardInputs)+
 		len(m.DashboardTables)+
 		len(m.DashboardTexts)+
+		len(m.Exemptions)+
 		len(m.References) == 0
 }
 
@@ -649,6 +667,11 @@ func (m *ResourceMaps) WalkResources(resourceFunc func(item HclResource) (bool,
 			return err
 		}
 	}
+	for _, r := range m.Exemptions {
+		if continueWalking, err := resourceFunc(r); err != nil || !continueWalking {
+			return err
+		}
+	}
 	for _, r := range m.GlobalDashboardInputs {
 		if continueWalking, err := resourceFunc(r); err != nil || !continueWalking {
 			return err
@@ -827,6 +850,14 @@ func (m *ResourceMaps) AddResource(item HclResource) hcl.Diagnostics {
 		}
 		m.DashboardTexts[name] = r
 
+	case *Exemption:
+		name := r.Name()
+		if existing, ok := m.Exemptions[name]; ok {
+			diags = append(diags, checkForDuplicate(existing, item)...)
+			break
+		}
+		m.Exemptions[name] = r
+
 	case *Variable:
 		// NOTE: add variable by unqualified name
 		name := r.UnqualifiedName
@@ -908,6 +939,9 @@ func (m *ResourceMaps) Merge(others []*ResourceMaps) *ResourceMaps {
 		for k, v := range source.DashboardTexts {
 			res.DashboardTexts[k] = v
 		}
+		for k, v := range source.Exemptions {
+			res.Exemptions[k] = v
+		}
 		for k, v := range source.GlobalDashboardInputs {
 			res.GlobalDashboardInputs[k] = v
 		}
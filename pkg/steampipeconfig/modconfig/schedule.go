@@ -0,0 +1,70 @@
+package modconfig
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/robfig/cron/v3"
+	"github.com/turbot/pipe-fittings/hclhelpers"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+// Schedule is a dashboard or benchmark which the dashboard service runs on a cron schedule
+type Schedule struct {
+	Name string `hcl:"name,label" json:"name"`
+	// standard 5 field cron expression, or a descriptor such as @daily - times are in the local timezone
+	// unless the expression is prefixed with CRON_TZ=<timezone>
+	Cron string `hcl:"cron" json:"cron"`
+	// the dashboard or benchmark to run, e.g. benchmark.cis_v150
+	Target string `hcl:"target" json:"target"`
+	// map of input name to value - names may be given with or without the 'input.' prefix
+	Inputs map[string]string `hcl:"inputs,optional" json:"inputs,omitempty"`
+	// export formats or file names - defaults to a snapshot
+	// dashboards may only be exported as snapshots, benchmarks may use any check export format (e.g. csv, sarif)
+	Export []string `hcl:"export,optional" json:"export,omitempty"`
+	// the directory the exports are written to
+	SnapshotLocation *string `hcl:"snapshot_location,optional" json:"snapshot_location,omitempty"`
+
+	FileName        *string `json:"-"`
+	StartLineNumber *int    `json:"-"`
+	EndLineNumber   *int    `json:"-"`
+}
+
+func (s *Schedule) OnDecoded(block *hcl.Block) hcl.Diagnostics {
+	scheduleRange := hclhelpers.BlockRange(block)
+	s.FileName = &scheduleRange.Filename
+	s.StartLineNumber = &scheduleRange.Start.Line
+	s.EndLineNumber = &scheduleRange.End.Line
+	if len(s.Export) == 0 {
+		s.Export = []string{constants.OutputFormatSnapshot}
+	}
+
+	var diags hcl.Diagnostics
+	if _, err := cron.ParseStandard(s.Cron); err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("invalid cron expression '%s' for schedule '%s': %s", s.Cron, s.Name, err.Error()),
+			Subject:  &scheduleRange,
+		})
+	}
+	if parsedName, err := ParseResourceName(s.Target); err != nil || (parsedName.ItemType != BlockTypeDashboard && parsedName.ItemType != BlockTypeBenchmark) {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  fmt.Sprintf("invalid target '%s' for schedule '%s' - must be a dashboard or benchmark name, e.g. benchmark.my_benchmark", s.Target, s.Name),
+			Subject:  &scheduleRange,
+		})
+	}
+	return diags
+}
+
+// InputValues returns the schedule inputs, keyed by the full input name
+func (s *Schedule) InputValues() map[string]any {
+	res := make(map[string]any, len(s.Inputs))
+	for name, value := range s.Inputs {
+		if parsedName, err := ParseResourceName(name); err != nil || parsedName.ItemType != BlockTypeInput {
+			name = BuildModResourceName(BlockTypeInput, name)
+		}
+		res[name] = value
+	}
+	return res
+}
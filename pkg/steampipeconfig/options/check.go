@@ -16,6 +16,12 @@ type Check struct {
 	Separator *string `hcl:"separator" cty:"check_separator"`
 	Header    *bool   `hcl:"header" cty:"check_header"`
 	Timing    *string `hcl:"timing" cty:"check_timing"`
+	// per-control execution timeout, in seconds
+	ControlTimeout *int `hcl:"control_timeout" cty:"check_control_timeout"`
+	// number of times a control which errors is retried
+	ControlRetries *int `hcl:"control_retries" cty:"check_control_retries"`
+	// initial delay before retrying a control, in seconds - doubled for each subsequent retry
+	ControlRetryBackoff *int `hcl:"control_retry_backoff" cty:"check_control_retry_backoff"`
 }
 
 func (t *Check) SetBaseProperties(otherOptions Options) {
@@ -35,6 +41,15 @@ func (t *Check) SetBaseProperties(otherOptions Options) {
 		if t.Header == nil && o.Header != nil {
 			t.Header = o.Header
 		}
+		if t.ControlTimeout == nil && o.ControlTimeout != nil {
+			t.ControlTimeout = o.ControlTimeout
+		}
+		if t.ControlRetries == nil && o.ControlRetries != nil {
+			t.ControlRetries = o.ControlRetries
+		}
+		if t.ControlRetryBackoff == nil && o.ControlRetryBackoff != nil {
+			t.ControlRetryBackoff = o.ControlRetryBackoff
+		}
 	}
 }
 
@@ -54,6 +69,15 @@ func (t *Check) ConfigMap() map[string]interface{} {
 	if t.Timing != nil {
 		res[constants.ArgTiming] = t.Timing
 	}
+	if t.ControlTimeout != nil {
+		res[constants.ArgControlTimeout] = t.ControlTimeout
+	}
+	if t.ControlRetries != nil {
+		res[constants.ArgControlRetries] = t.ControlRetries
+	}
+	if t.ControlRetryBackoff != nil {
+		res[constants.ArgControlRetryBackoff] = t.ControlRetryBackoff
+	}
 	return res
 }
 
@@ -74,6 +98,15 @@ func (t *Check) Merge(otherOptions Options) {
 		if o.Timing != nil {
 			t.Timing = o.Timing
 		}
+		if o.ControlTimeout != nil {
+			t.ControlTimeout = o.ControlTimeout
+		}
+		if o.ControlRetries != nil {
+			t.ControlRetries = o.ControlRetries
+		}
+		if o.ControlRetryBackoff != nil {
+			t.ControlRetryBackoff = o.ControlRetryBackoff
+		}
 	}
 }
 
@@ -102,6 +135,21 @@ func (t *Check) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  Timing: %v", *t.Timing))
 	}
+	if t.ControlTimeout == nil {
+		str = append(str, "  ControlTimeout: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  ControlTimeout: %d", *t.ControlTimeout))
+	}
+	if t.ControlRetries == nil {
+		str = append(str, "  ControlRetries: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  ControlRetries: %d", *t.ControlRetries))
+	}
+	if t.ControlRetryBackoff == nil {
+		str = append(str, "  ControlRetryBackoff: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  ControlRetryBackoff: %d", *t.ControlRetryBackoff))
+	}
 	return strings.Join(str, "\n")
 }
 
@@ -18,6 +18,18 @@ type GlobalDashboard struct {
 	Port         *int    `hcl:"port"`
 	Listen       *string `hcl:"listen"`
 	StartTimeout *int    `hcl:"start_timeout"`
+	// server authentication
+	AuthToken          *string `hcl:"auth_token"`
+	AuthUsername       *string `hcl:"auth_username"`
+	AuthPassword       *string `hcl:"auth_password"`
+	AuthProxyHeader    *string `hcl:"auth_proxy_header"`
+	AuthTrustedProxies *string `hcl:"auth_trusted_proxies"`
+	// server tls
+	TLS     *bool   `hcl:"tls"`
+	TLSCert *string `hcl:"tls_cert"`
+	TLSKey  *string `hcl:"tls_key"`
+	// server rest api - the query endpoint is only enabled by default if authentication is configured
+	EnableQueryAPI *bool `hcl:"enable_query_api"`
 }
 
 func (t *WorkspaceProfileDashboard) SetBaseProperties(otherOptions Options) {
@@ -83,6 +95,34 @@ func (d *GlobalDashboard) ConfigMap() map[string]interface{} {
 	} else {
 		res[constants.ArgDashboardStartTimeout] = constants.DashboardStartTimeout.Seconds()
 	}
+	if d.AuthToken != nil {
+		res[constants.ArgDashboardAuthToken] = d.AuthToken
+	}
+	if d.AuthUsername != nil {
+		res[constants.ArgDashboardAuthUsername] = d.AuthUsername
+	}
+	if d.AuthPassword != nil {
+		res[constants.ArgDashboardAuthPassword] = d.AuthPassword
+	}
+	if d.AuthProxyHeader != nil {
+		res[constants.ArgDashboardAuthProxyHeader] = d.AuthProxyHeader
+	}
+	if d.AuthTrustedProxies != nil {
+		// convert from comma separated string to array
+		res[constants.ArgDashboardAuthTrustedProxies] = searchPathToArray(*d.AuthTrustedProxies)
+	}
+	if d.TLS != nil {
+		res[constants.ArgDashboardTLS] = d.TLS
+	}
+	if d.TLSCert != nil {
+		res[constants.ArgDashboardTLSCert] = d.TLSCert
+	}
+	if d.TLSKey != nil {
+		res[constants.ArgDashboardTLSKey] = d.TLSKey
+	}
+	if d.EnableQueryAPI != nil {
+		res[constants.ArgDashboardEnableQueryAPI] = d.EnableQueryAPI
+	}
 	return res
 }
 
@@ -100,6 +140,33 @@ func (d *GlobalDashboard) Merge(otherOptions Options) {
 		if o.StartTimeout != nil {
 			d.StartTimeout = o.StartTimeout
 		}
+		if o.AuthToken != nil {
+			d.AuthToken = o.AuthToken
+		}
+		if o.AuthUsername != nil {
+			d.AuthUsername = o.AuthUsername
+		}
+		if o.AuthPassword != nil {
+			d.AuthPassword = o.AuthPassword
+		}
+		if o.AuthProxyHeader != nil {
+			d.AuthProxyHeader = o.AuthProxyHeader
+		}
+		if o.AuthTrustedProxies != nil {
+			d.AuthTrustedProxies = o.AuthTrustedProxies
+		}
+		if o.TLS != nil {
+			d.TLS = o.TLS
+		}
+		if o.TLSCert != nil {
+			d.TLSCert = o.TLSCert
+		}
+		if o.TLSKey != nil {
+			d.TLSKey = o.TLSKey
+		}
+		if o.EnableQueryAPI != nil {
+			d.EnableQueryAPI = o.EnableQueryAPI
+		}
 	}
 }
 
@@ -123,5 +190,51 @@ func (d *GlobalDashboard) String() string {
 	} else {
 		str = append(str, fmt.Sprintf("  StartTimeout: %d", *d.StartTimeout))
 	}
+	// do not show the token or password
+	if d.AuthToken == nil {
+		str = append(str, "  AuthToken: nil")
+	} else {
+		str = append(str, "  AuthToken: ********")
+	}
+	if d.AuthUsername == nil {
+		str = append(str, "  AuthUsername: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  AuthUsername: %s", *d.AuthUsername))
+	}
+	if d.AuthPassword == nil {
+		str = append(str, "  AuthPassword: nil")
+	} else {
+		str = append(str, "  AuthPassword: ********")
+	}
+	if d.AuthProxyHeader == nil {
+		str = append(str, "  AuthProxyHeader: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  AuthProxyHeader: %s", *d.AuthProxyHeader))
+	}
+	if d.AuthTrustedProxies == nil {
+		str = append(str, "  AuthTrustedProxies: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  AuthTrustedProxies: %s", *d.AuthTrustedProxies))
+	}
+	if d.TLS == nil {
+		str = append(str, "  TLS: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TLS: %v", *d.TLS))
+	}
+	if d.TLSCert == nil {
+		str = append(str, "  TLSCert: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TLSCert: %s", *d.TLSCert))
+	}
+	if d.TLSKey == nil {
+		str = append(str, "  TLSKey: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  TLSKey: %s", *d.TLSKey))
+	}
+	if d.EnableQueryAPI == nil {
+		str = append(str, "  EnableQueryAPI: nil")
+	} else {
+		str = append(str, fmt.Sprintf("  EnableQueryAPI: %v", *d.EnableQueryAPI))
+	}
 	return strings.Join(str, "\n")
 }
@@ -161,6 +161,8 @@ func decodeBlock(block *hcl.Block, parseCtx *ModParseContext) (modconfig.HclReso
 			resource, res = decodeVariable(block, parseCtx)
 		case modconfig.BlockTypeBenchmark:
 			resource, res = decodeBenchmark(block, parseCtx)
+		case modconfig.BlockTypeExemption:
+			resource, res = decodeExemption(block, parseCtx)
 		default:
 			// all other blocks are treated the same:
 			resource, res = decodeResource(block, parseCtx)
@@ -631,6 +633,46 @@ func decodeBenchmark(block *hcl.Block, parseCtx *ModParseContext) (*modconfig.Be
 	return benchmark, res
 }
 
+func decodeExemption(block *hcl.Block, parseCtx *ModParseContext) (*modconfig.Exemption, *DecodeResult) {
+	res := newDecodeResult()
+	exemption := modconfig.NewExemption(block, parseCtx.CurrentMod, parseCtx.DetermineBlockName(block)).(*modconfig.Exemption)
+	content, diags := block.Body.Content(ExemptionBlockSchema)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "controls", &exemption.ControlNames, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "resources", &exemption.Resources, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "dimensions", &exemption.Dimensions, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "reason", &exemption.Reason, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "expires", &exemption.Expires, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "description", &exemption.Description, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "tags", &exemption.Tags, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	diags = decodeProperty(content, "title", &exemption.Title, parseCtx.EvalCtx)
+	res.handleDecodeDiags(diags)
+
+	// now resolve the target controls and benchmarks
+	if res.Success() {
+		supportedTargets := []string{modconfig.BlockTypeBenchmark, modconfig.BlockTypeControl}
+		controls, diags := resolveChildrenFromNames(exemption.ControlNames.StringList(), block, supportedTargets, parseCtx)
+		res.handleDecodeDiags(diags)
+		exemption.SetControls(controls)
+	}
+	return exemption, res
+}
+
 func decodeProperty(content *hcl.BodyContent, property string, dest interface{}, evalCtx *hcl.EvalContext) hcl.Diagnostics {
 	var diags hcl.Diagnostics
 	if attr, ok := content.Attributes[property]; ok {
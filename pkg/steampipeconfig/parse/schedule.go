@@ -0,0 +1,20 @@
+package parse
+
+import (
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+func DecodeSchedule(block *hcl.Block) (*modconfig.Schedule, hcl.Diagnostics) {
+	var schedule = &modconfig.Schedule{
+		// populate name from label
+		Name: block.Labels[0],
+	}
+	diags := gohcl.DecodeBody(block.Body, nil, schedule)
+	if !diags.HasErrors() {
+		diags = append(diags, schedule.OnDecoded(block)...)
+	}
+
+	return schedule, diags
+}
@@ -0,0 +1,92 @@
+package parse
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/turbot/steampipe/pkg/constants"
+)
+
+type decodeScheduleTest struct {
+	input          string
+	expectedError  bool
+	expectedExport []string
+	expectedInputs map[string]any
+}
+
+var testCasesDecodeSchedule = map[string]decodeScheduleTest{
+	"benchmark with default export": {
+		input: `schedule "nightly" {
+  cron   = "0 2 * * *"
+  target = "benchmark.cis_v150"
+}`,
+		expectedExport: []string{constants.OutputFormatSnapshot},
+		expectedInputs: map[string]any{},
+	},
+	"dashboard with inputs and exports": {
+		input: `schedule "hourly" {
+  cron   = "@hourly"
+  target = "aws_insights.dashboard.vpc_detail"
+  inputs = {
+    vpc_id        = "vpc-123"
+    "input.region" = "us-east-1"
+  }
+  export = ["report.sps"]
+}`,
+		expectedExport: []string{"report.sps"},
+		expectedInputs: map[string]any{"input.vpc_id": "vpc-123", "input.region": "us-east-1"},
+	},
+	"invalid cron": {
+		input: `schedule "invalid" {
+  cron   = "every day"
+  target = "benchmark.cis_v150"
+}`,
+		expectedError: true,
+	},
+	"query target": {
+		input: `schedule "invalid" {
+  cron   = "0 2 * * *"
+  target = "query.my_query"
+}`,
+		expectedError: true,
+	},
+	"missing target": {
+		input: `schedule "invalid" {
+  cron = "0 2 * * *"
+}`,
+		expectedError: true,
+	},
+}
+
+func TestDecodeSchedule(t *testing.T) {
+	for name, test := range testCasesDecodeSchedule {
+		file, diags := hclsyntax.ParseConfig([]byte(test.input), "test.spc", hcl.InitialPos)
+		if diags.HasErrors() {
+			t.Fatalf("Test: '%s' FAILED to parse: %s", name, diags.Error())
+		}
+		content, diags := file.Body.Content(ConfigBlockSchema)
+		if diags.HasErrors() {
+			t.Fatalf("Test: '%s' FAILED to parse: %s", name, diags.Error())
+		}
+
+		schedule, diags := DecodeSchedule(content.Blocks[0])
+		if test.expectedError {
+			if !diags.HasErrors() {
+				t.Errorf("Test: '%s' FAILED - expected error", name)
+			}
+			continue
+		}
+		if diags.HasErrors() {
+			t.Errorf("Test: '%s' FAILED with unexpected error: %s", name, diags.Error())
+			continue
+		}
+		if !reflect.DeepEqual(schedule.Export, test.expectedExport) {
+			t.Errorf("Test: '%s' FAILED : expected export %v, got %v", name, test.expectedExport, schedule.Export)
+		}
+		if inputs := schedule.InputValues(); !reflect.DeepEqual(inputs, test.expectedInputs) {
+			t.Errorf("Test: '%s' FAILED : expected inputs %v, got %v", name, test.expectedInputs, inputs)
+		}
+	}
+}
@@ -27,6 +27,10 @@ var ConfigBlockSchema = &hcl.BodySchema{
 			Type:       modconfig.BlockTypeWorkspaceProfile,
 			LabelNames: []string{"name"},
 		},
+		{
+			Type:       modconfig.BlockTypeSchedule,
+			LabelNames: []string{"name"},
+		},
 	},
 }
 var PluginBlockSchema = &hcl.BodySchema{
@@ -148,6 +152,10 @@ var WorkspaceBlockSchema = &hcl.BodySchema{
 		{
 			Type: modconfig.BlockTypeLocals,
 		},
+		{
+			Type:       modconfig.BlockTypeExemption,
+			LabelNames: []string{"name"},
+		},
 		{
 			Type:       modconfig.BlockTypeCategory,
 			LabelNames: []string{"name"},
@@ -267,6 +275,19 @@ var BenchmarkBlockSchema = &hcl.BodySchema{
 	},
 }
 
+var ExemptionBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{
+		{Name: "controls", Required: true},
+		{Name: "resources"},
+		{Name: "dimensions"},
+		{Name: "reason", Required: true},
+		{Name: "expires"},
+		{Name: "description"},
+		{Name: "tags"},
+		{Name: "title"},
+	},
+}
+
 // QueryProviderBlockSchema schema for all blocks satisfying QueryProvider interface
 // NOTE: these are just the blocks/attributes that are explicitly decoded
 // other query provider properties are implicitly decoded using tags
@@ -28,6 +28,8 @@ type SteampipeConfig struct {
 	PluginsInstances map[string]*modconfig.Plugin
 	// map of connection name to partially parsed connection config
 	Connections map[string]*modconfig.Connection
+	// map of schedule name to the schedules run by the dashboard service
+	Schedules map[string]*modconfig.Schedule
 
 	// Steampipe options
 	DefaultConnectionOptions *options.Connection
@@ -45,6 +47,7 @@ func NewSteampipeConfig(commandName string) *SteampipeConfig {
 		Connections:      make(map[string]*modconfig.Connection),
 		Plugins:          make(map[string][]*modconfig.Plugin),
 		PluginsInstances: make(map[string]*modconfig.Plugin),
+		Schedules:        make(map[string]*modconfig.Schedule),
 	}
 }
 
@@ -322,6 +325,17 @@ func duplicatePluginError(existingPlugin, newPlugin *modconfig.Plugin) error {
 		*newPlugin.FileName, *newPlugin.StartLineNumber)
 }
 
+// add a schedule to Schedules - this errors if there is already a schedule with the same name
+func (c *SteampipeConfig) addSchedule(schedule *modconfig.Schedule) error {
+	if existingSchedule, exists := c.Schedules[schedule.Name]; exists {
+		return sperr.New("duplicate schedule: '%s'\n\t(%s:%d)\n\t(%s:%d)",
+			schedule.Name, *existingSchedule.FileName, *existingSchedule.StartLineNumber,
+			*schedule.FileName, *schedule.StartLineNumber)
+	}
+	c.Schedules[schedule.Name] = schedule
+	return nil
+}
+
 // ensure we have a plugin config struct for all plugins mentioned in connection config,
 // even if there is not an explicit HCL config for it
 // NOTE: this populates the  Plugin and PluginInstance field of the connections
@@ -0,0 +1,118 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/turbot/steampipe/pkg/db/db_common"
+	"github.com/turbot/steampipe/pkg/error_helpers"
+	"github.com/turbot/steampipe/pkg/steampipeconfig/modconfig"
+)
+
+// SavedQueriesFileName is the name of the file in the workspace folder which saved queries are written to
+const SavedQueriesFileName = "saved_queries.sp"
+
+var positionalParamRegex = regexp.MustCompile(`\$(\d+)`)
+
+// SaveQuery adds a query block with the given name and sql to the saved queries file of the workspace
+// and reloads the workspace so the query is available immediately
+// a param block is added for each positional parameter ($1, $2...) used by the sql
+func (w *Workspace) SaveQuery(ctx context.Context, client db_common.Client, name, sql string) error {
+	if !hclsyntax.ValidIdentifier(name) {
+		return fmt.Errorf("'%s' is not a valid query name - names must start with a letter and contain only letters, digits, underscores and hyphens", name)
+	}
+	if _, exists := w.GetQueryProvider(fmt.Sprintf("query.%s", name)); exists {
+		return fmt.Errorf("query.%s already exists", name)
+	}
+	sql = strings.TrimSpace(sql)
+	if sql == "" {
+		return fmt.Errorf("no query to save")
+	}
+
+	path := filepath.Join(w.Path, SavedQueriesFileName)
+	prevContent, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := os.WriteFile(path, append(prevContent, []byte(buildQueryBlock(name, sql))...), 0644); err != nil {
+		return err
+	}
+
+	// reload the workspace - if this fails, restore the file so we leave the workspace as we found it
+	if errAndWarnings := w.reloadWorkspaceMod(ctx); errAndWarnings.GetError() != nil {
+		if len(prevContent) == 0 {
+			os.Remove(path)
+		} else {
+			os.WriteFile(path, prevContent, 0644)
+		}
+		return error_helpers.PrefixError(errAndWarnings.GetError(), "failed to save query")
+	}
+	w.onNewIntrospectionData(ctx, client)
+	return nil
+}
+
+// SavedQueries returns the queries in the saved queries file of the workspace, sorted by name
+func (w *Workspace) SavedQueries() []*modconfig.Query {
+	path := filepath.Join(w.Path, SavedQueriesFileName)
+	var res []*modconfig.Query
+	for _, query := range w.GetResourceMaps().Queries {
+		if declRange := query.GetDeclRange(); declRange != nil && filepath.Clean(declRange.Filename) == path {
+			res = append(res, query)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].ShortName < res[j].ShortName
+	})
+	return res
+}
+
+// reloadWorkspaceMod reloads the workspace mod
+// unlike reloadResourceMaps, errors are returned to the caller rather than passed to the file watcher error handler
+func (w *Workspace) reloadWorkspaceMod(ctx context.Context) error_helpers.ErrorAndWarnings {
+	w.loadLock.Lock()
+	defer w.loadLock.Unlock()
+
+	inputVariables, errAndWarnings := w.PopulateVariables(ctx)
+	if errAndWarnings.GetError() != nil {
+		return errAndWarnings
+	}
+	return w.LoadWorkspaceMod(ctx, inputVariables)
+}
+
+// buildQueryBlock returns the HCL for a query block with the given name and sql
+func buildQueryBlock(name, sql string) string {
+	// escape template sequences - the sql is written as a heredoc template
+	sql = strings.ReplaceAll(sql, "${", "$${")
+	sql = strings.ReplaceAll(sql, "%{", "%%{")
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("\nquery \"%s\" {\n", name))
+	sb.WriteString("  sql = <<-EOQ\n")
+	for _, line := range strings.Split(sql, "\n") {
+		sb.WriteString(fmt.Sprintf("    %s\n", strings.TrimRight(line, " \t\r")))
+	}
+	sb.WriteString("  EOQ\n")
+	for i := 1; i <= maxPositionalParam(sql); i++ {
+		sb.WriteString(fmt.Sprintf("\n  param \"p%d\" {}\n", i))
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// maxPositionalParam returns the highest positional parameter number used in the sql
+func maxPositionalParam(sql string) int {
+	res := 0
+	for _, match := range positionalParamRegex.FindAllStringSubmatch(sql, -1) {
+		if n, err := strconv.Atoi(match[1]); err == nil && n > res {
+			res = n
+		}
+	}
+	return res
+}